@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/mix"
+
+	"gitlab.com/rangerdanger/sdlaudio"
+)
+
+// SDL plays the game's audio through sdlaudio and SDL_mixer
+type SDL struct {
+	effects map[string]*mix.Chunk
+}
+
+// NewSDL returns an SDL audio backend, sdlaudio must be initialised first
+func NewSDL() *SDL {
+	return &SDL{effects: make(map[string]*mix.Chunk)}
+}
+
+// Close frees the loaded sound effects
+func (a *SDL) Close() {
+	for _, chunk := range a.effects {
+		chunk.Free()
+	}
+}
+
+// SetVolume sets the music and effects volumes as percentages
+func (a *SDL) SetVolume(music, effects int) {
+	mix.VolumeMusic(music * mix.MAX_VOLUME / 100)
+	mix.Volume(-1, effects*mix.MAX_VOLUME/100)
+}
+
+// LoadMusic loads a music track under name
+func (a *SDL) LoadMusic(name, path string) error {
+	return sdlaudio.LoadMusic(name, path)
+}
+
+// LoadEffect loads a sound effect sample under name
+func (a *SDL) LoadEffect(name, path string) error {
+	chunk, err := mix.LoadWAV(path)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	a.effects[name] = chunk
+	return nil
+}
+
+// PlayMusic plays a track loops times, -1 looping forever
+func (a *SDL) PlayMusic(track string, loops int) error {
+	return sdlaudio.PlayMusic(track, loops)
+}
+
+// FadeOutMusic fades the playing track out over ms milliseconds
+func (a *SDL) FadeOutMusic(ms int) {
+	mix.FadeOutMusic(ms)
+}
+
+// PlayEffect plays a sound effect on the first free channel. Effects
+// which failed to load stay silent
+func (a *SDL) PlayEffect(name string) error {
+	chunk, ok := a.effects[name]
+	if !ok {
+		return nil
+	}
+
+	_, err := chunk.Play(-1, 0)
+	return err
+}
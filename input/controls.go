@@ -0,0 +1,59 @@
+package input
+
+import "gitlab.com/rangerdanger/tetris/tetris"
+
+// Kinds of physical input
+const (
+	keyInput = iota
+	buttonInput
+	axisInput
+)
+
+// A physical input, a key or a gamepad button or stick direction
+type source struct {
+	device int32 // joystick instance, 0 for the keyboard
+	kind   int
+	code   int32
+}
+
+// Controls feeds the game's commands from the keyboard and gamepads. A
+// command stays held while any key, button or stick bound to it is held
+type Controls struct {
+	game *tetris.Game
+	held map[int32]map[source]bool
+}
+
+// NewControls returns controls pressing and releasing commands on g
+func NewControls(g *tetris.Game) *Controls {
+	return &Controls{game: g, held: make(map[int32]map[source]bool)}
+}
+
+// Press command on behalf of an input
+func (c *Controls) press(command int32, from source) {
+	if c.held[command] == nil {
+		c.held[command] = make(map[source]bool)
+	}
+
+	c.held[command][from] = true
+	c.game.Press(command)
+}
+
+// Release command on behalf of an input, the game only sees the release
+// once no other input holds it
+func (c *Controls) release(command int32, from source) {
+	delete(c.held[command], from)
+	if len(c.held[command]) == 0 {
+		c.game.Release(command)
+	}
+}
+
+// Release every command held by the inputs of a device
+func (c *Controls) releaseDevice(device int32, kind int) {
+	for command, sources := range c.held {
+		for from := range sources {
+			if from.device == device && from.kind == kind {
+				c.release(command, from)
+			}
+		}
+	}
+}
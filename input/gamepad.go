@@ -0,0 +1,127 @@
+package input
+
+import (
+	"log"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"gitlab.com/rangerdanger/tetris/tetris"
+)
+
+// Game commands of the gamepad buttons. The D-pad shifts and drops, A
+// and B rotate as on the arcade cabinet, X rotates 180 degrees and Y holds
+var buttonCommands = map[uint8]int32{
+	uint8(sdl.CONTROLLER_BUTTON_DPAD_LEFT):  tetris.ShiftLeft,
+	uint8(sdl.CONTROLLER_BUTTON_DPAD_RIGHT): tetris.ShiftRight,
+	uint8(sdl.CONTROLLER_BUTTON_DPAD_DOWN):  tetris.ManualDrop,
+	uint8(sdl.CONTROLLER_BUTTON_A):          tetris.RotateCounterClockwise,
+	uint8(sdl.CONTROLLER_BUTTON_B):          tetris.RotateClockwise,
+	uint8(sdl.CONTROLLER_BUTTON_X):          tetris.Rotate180,
+	uint8(sdl.CONTROLLER_BUTTON_Y):          tetris.Hold,
+	uint8(sdl.CONTROLLER_BUTTON_START):      tetris.Start,
+}
+
+// Game commands of the left stick pushed towards either end of an axis
+var axisCommands = map[uint8][2]int32{
+	uint8(sdl.CONTROLLER_AXIS_LEFTX): {tetris.ShiftLeft, tetris.ShiftRight},
+	uint8(sdl.CONTROLLER_AXIS_LEFTY): {0, tetris.ManualDrop},
+}
+
+// Largest distance an analog stick travels from its centre
+const axisMax = 32767
+
+// Gamepads maps the buttons and sticks of SDL game controllers to game
+// commands, opening controllers as they are plugged in
+type Gamepads struct {
+	controllers map[sdl.JoystickID]*sdl.GameController
+	deadzones   map[uint8]int
+}
+
+// NewGamepads returns gamepads ignoring the left stick within deadzoneX
+// and deadzoneY percent of its travel from the centre
+func NewGamepads(deadzoneX, deadzoneY int) *Gamepads {
+	return &Gamepads{
+		controllers: make(map[sdl.JoystickID]*sdl.GameController),
+		deadzones: map[uint8]int{
+			uint8(sdl.CONTROLLER_AXIS_LEFTX): deadzoneX * axisMax / 100,
+			uint8(sdl.CONTROLLER_AXIS_LEFTY): deadzoneY * axisMax / 100,
+		},
+	}
+}
+
+// Added opens the controller at a device index, joysticks SDL has no
+// controller mapping for are skipped
+func (p *Gamepads) Added(index int) {
+	if !sdl.IsGameController(index) {
+		return
+	}
+
+	controller := sdl.GameControllerOpen(index)
+	if controller == nil {
+		log.Printf("gamepad %d: %v\n", index, sdl.GetError())
+		return
+	}
+
+	p.controllers[controller.Joystick().InstanceID()] = controller
+}
+
+// Removed closes an unplugged controller, releasing everything it held
+func (p *Gamepads) Removed(c *Controls, id sdl.JoystickID) {
+	controller, ok := p.controllers[id]
+	if !ok {
+		return
+	}
+
+	c.releaseDevice(int32(id), buttonInput)
+	c.releaseDevice(int32(id), axisInput)
+	controller.Close()
+	delete(p.controllers, id)
+}
+
+// Button presses or releases the command bound to a button
+func (p *Gamepads) Button(c *Controls, id sdl.JoystickID, button uint8, pressed bool) {
+	command, ok := buttonCommands[button]
+	if !ok {
+		return
+	}
+
+	from := source{device: int32(id), kind: buttonInput, code: int32(button)}
+	if pressed {
+		c.press(command, from)
+	} else {
+		c.release(command, from)
+	}
+}
+
+// Axis presses the command for the end of an axis the stick is pushed
+// past the deadzone towards, releasing it once the stick returns
+func (p *Gamepads) Axis(c *Controls, id sdl.JoystickID, axis uint8, value int16) {
+	commands, ok := axisCommands[axis]
+	if !ok {
+		return
+	}
+
+	deadzone := p.deadzones[axis]
+	pushed := [2]bool{int(value) < -deadzone, int(value) > deadzone}
+
+	for i, command := range commands {
+		if command == 0 {
+			continue
+		}
+
+		from := source{device: int32(id), kind: axisInput, code: int32(axis)*2 + int32(i)}
+		if pushed[i] {
+			c.press(command, from)
+		} else {
+			c.release(command, from)
+		}
+	}
+}
+
+// Close closes every open controller
+func (p *Gamepads) Close() {
+	for id, controller := range p.controllers {
+		controller.Close()
+		delete(p.controllers, id)
+	}
+}
@@ -0,0 +1,170 @@
+package input
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"gitlab.com/rangerdanger/tetris/tetris"
+)
+
+// Action is a game command as named in the settings file and shown on
+// the rebind screen
+type Action struct {
+	Name    string
+	Label   string
+	Command int32
+}
+
+// Actions lists the bindable commands in the order the rebind screen
+// asks for them
+var Actions = []Action{
+	{Name: "shift_left", Label: "SHIFT LEFT", Command: tetris.ShiftLeft},
+	{Name: "shift_right", Label: "SHIFT RIGHT", Command: tetris.ShiftRight},
+	{Name: "rotate_cw", Label: "ROTATE CW", Command: tetris.RotateClockwise},
+	{Name: "rotate_ccw", Label: "ROTATE CCW", Command: tetris.RotateCounterClockwise},
+	{Name: "rotate_180", Label: "ROTATE 180", Command: tetris.Rotate180},
+	{Name: "drop", Label: "DROP", Command: tetris.ManualDrop},
+	{Name: "hold", Label: "HOLD", Command: tetris.Hold},
+	{Name: "start", Label: "START", Command: tetris.Start},
+}
+
+// Keymap binds keys to game commands, any number of keys may be bound
+// to a command
+type Keymap struct {
+	bindings map[int32][]sdl.Keycode
+}
+
+// DefaultKeymap returns the arrow keys, X, Space, C and Return bindings
+func DefaultKeymap() *Keymap {
+	k := &Keymap{bindings: make(map[int32][]sdl.Keycode)}
+	k.Bind(tetris.ShiftLeft, sdl.K_LEFT)
+	k.Bind(tetris.ShiftRight, sdl.K_RIGHT)
+	k.Bind(tetris.RotateClockwise, sdl.K_UP)
+	k.Bind(tetris.RotateCounterClockwise, sdl.K_DOWN)
+	k.Bind(tetris.Rotate180, sdl.K_x)
+	k.Bind(tetris.ManualDrop, sdl.K_SPACE)
+	k.Bind(tetris.Hold, sdl.K_c)
+	k.Bind(tetris.Start, sdl.K_RETURN)
+	return k
+}
+
+// SettingsPath returns where the keymap is kept in the user's config directory
+func SettingsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "golang-tetris", "keymap.json"), nil
+}
+
+// LoadKeymap reads a keymap from a JSON file mapping action names to
+// lists of SDL key names, e.g. {"hold": ["C", "Left Shift"]}. Actions
+// the file leaves out keep their default keys
+func LoadKeymap(path string) (*Keymap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var names map[string][]string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	k := DefaultKeymap()
+	for action, keys := range names {
+		a, ok := findAction(action)
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown action %q", path, action)
+		}
+
+		codes := make([]sdl.Keycode, len(keys))
+		for i, name := range keys {
+			codes[i] = sdl.GetKeyFromName(name)
+			if codes[i] == sdl.K_UNKNOWN {
+				return nil, fmt.Errorf("%s: unknown key %q", path, name)
+			}
+		}
+		k.Bind(a.Command, codes...)
+	}
+
+	return k, nil
+}
+
+// Save writes the keymap to a JSON file, creating its directory
+func (k *Keymap) Save(path string) error {
+	names := make(map[string][]string)
+	for _, a := range Actions {
+		names[a.Name] = k.KeyNames(a.Command)
+	}
+
+	data, err := json.MarshalIndent(names, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Bind replaces the keys bound to a command
+func (k *Keymap) Bind(command int32, keys ...sdl.Keycode) {
+	k.bindings[command] = keys
+}
+
+// KeyNames returns the names of the keys bound to a command
+func (k *Keymap) KeyNames(command int32) []string {
+	names := make([]string, len(k.bindings[command]))
+	for i, key := range k.bindings[command] {
+		names[i] = sdl.GetKeyName(key)
+	}
+
+	return names
+}
+
+// KeyDown presses every command bound to key
+func (k *Keymap) KeyDown(c *Controls, key sdl.Keycode) {
+	for _, command := range k.commands(key) {
+		c.press(command, source{kind: keyInput, code: int32(key)})
+	}
+}
+
+// KeyUp releases every command bound to key
+func (k *Keymap) KeyUp(c *Controls, key sdl.Keycode) {
+	for _, command := range k.commands(key) {
+		c.release(command, source{kind: keyInput, code: int32(key)})
+	}
+}
+
+// Commands bound to a key
+func (k *Keymap) commands(key sdl.Keycode) []int32 {
+	var commands []int32
+	for command, keys := range k.bindings {
+		for _, v := range keys {
+			if v == key {
+				commands = append(commands, command)
+				break
+			}
+		}
+	}
+
+	return commands
+}
+
+func findAction(name string) (Action, bool) {
+	for _, a := range Actions {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return Action{}, false
+}
@@ -0,0 +1,35 @@
+package input
+
+import "github.com/veandco/go-sdl2/sdl"
+
+// Rebind is the rebind screen, stepping through the actions and asking
+// for a new key for each
+type Rebind struct {
+	keymap *Keymap
+	action int
+}
+
+// NewRebind starts rebinding the keys of a keymap from the first action
+func NewRebind(k *Keymap) *Rebind {
+	return &Rebind{keymap: k}
+}
+
+// KeyDown binds key to the current action in place of its old keys and
+// moves on to the next, Escape keeping the old keys. Returns true once
+// every action has been rebound
+func (r *Rebind) KeyDown(key sdl.Keycode) bool {
+	if key != sdl.K_ESCAPE {
+		r.keymap.Bind(Actions[r.action].Command, key)
+	}
+
+	r.action++
+	return r.action >= len(Actions)
+}
+
+// Prompt returns the lines of text the rebind screen shows
+func (r *Rebind) Prompt() []string {
+	a := Actions[r.action]
+	lines := []string{"REBIND", a.Label}
+	lines = append(lines, r.keymap.KeyNames(a.Command)...)
+	return append(lines, "PRESS A KEY", "ESC TO KEEP")
+}
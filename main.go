@@ -1,24 +1,68 @@
 package main
 
 import (
-	"fmt"
+	"flag"
 	"log"
+	"os"
 
 	"github.com/veandco/go-sdl2/sdl"
 
 	"gitlab.com/rangerdanger/sdlaudio"
+	"gitlab.com/rangerdanger/tetris/audio"
+	"gitlab.com/rangerdanger/tetris/input"
+	"gitlab.com/rangerdanger/tetris/render"
 	"gitlab.com/rangerdanger/tetris/tetris"
 )
 
 const screenWidth int = 600
 const screenHeight int = 400
 
-const fps uint32 = 60
-const delayTime uint32 = 1000.0 / fps
+// Playfield layout
+const cellSize int32 = 20
+const boardX int32 = int32(screenWidth)/2 - (10*cellSize)/2
+const boardY int32 = 0
+
+const delayTime uint32 = 1000 / tetris.FPS
 
 const lockDelay int = 31
 
+var seed = flag.Int64("seed", 0, "randomizer seed replayed by every game, 0 seeds each game from the clock")
+var modeName = flag.String("mode", "tgm1", "game mode to play")
+var randomizer = flag.String("randomizer", "", "randomizer overriding the mode's")
+var rotation = flag.String("rotation", "", "rotation system overriding the mode's")
+var musicVolume = flag.Int("music-volume", 100, "music volume percentage")
+var effectsVolume = flag.Int("effects-volume", 100, "sound effects volume percentage")
+var deadzoneX = flag.Int("deadzone-x", 25, "gamepad stick deadzone percentage for shifting")
+var deadzoneY = flag.Int("deadzone-y", 50, "gamepad stick deadzone percentage for dropping")
+
+// Key opening the rebind screen from the menu
+const rebindKey = sdl.K_F1
+
 func main() {
+	flag.Parse()
+
+	mode, ok := tetris.Modes[*modeName]
+	if !ok {
+		log.Printf("unknown mode %q\n", *modeName)
+		return
+	}
+
+	if *randomizer != "" {
+		if _, ok := tetris.Randomizers[*randomizer]; !ok {
+			log.Printf("unknown randomizer %q\n", *randomizer)
+			return
+		}
+		mode.Randomizer = *randomizer
+	}
+
+	if *rotation != "" {
+		if _, ok := tetris.RotationSystems[*rotation]; !ok {
+			log.Printf("unknown rotation system %q\n", *rotation)
+			return
+		}
+		mode.Rotation = *rotation
+	}
+
 	if err := sdl.Init(sdl.INIT_EVERYTHING); err != nil {
 		log.Println(err)
 		return
@@ -46,35 +90,68 @@ func main() {
 		panic(err)
 	}
 	defer renderer.Destroy()
-	foo := tetris.NewGame()
+
+	font, err := render.LoadFont("assets/font.txt")
+	if err != nil {
+		log.Println(err)
+	}
+
+	view := render.New(renderer, font, boardX, boardY, cellSize)
+	sound := audio.NewSDL()
+	sound.SetVolume(*musicVolume, *effectsVolume)
+	foo := tetris.NewGame(mode, *seed, sound)
 	foo.Init()
 
+	keymap := input.DefaultKeymap()
+	settings, err := input.SettingsPath()
+	if err != nil {
+		log.Println(err)
+	} else if k, err := input.LoadKeymap(settings); err == nil {
+		keymap = k
+	} else if !os.IsNotExist(err) {
+		log.Println(err)
+	}
+	var rebind *input.Rebind
+
+	controls := input.NewControls(foo)
+	pads := input.NewGamepads(*deadzoneX, *deadzoneY)
+
 	// Main Loop
 	running := true
 	for running {
 		frameStart := sdl.GetTicks()
 
-		//foo.BufferCommand(0)
 		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
 			switch t := event.(type) {
 			case *sdl.KeyDownEvent:
-				switch t.Keysym.Sym {
-				case sdl.K_LEFT:
-					foo.BufferCommand(tetris.ShiftLeft)
-				case sdl.K_RIGHT:
-					foo.BufferCommand(tetris.ShiftRight)
-				case sdl.K_UP:
-					foo.BufferCommand(tetris.RotateClockwise)
-				case sdl.K_DOWN:
-					foo.BufferCommand(tetris.RotateCounterClockwise)
-				case sdl.K_SPACE:
-					foo.BufferCommand(tetris.ManualDrop)
-				case sdl.K_RETURN:
-					foo.BufferCommand(tetris.Start)
-					fmt.Println("STARTING")
+				if t.Repeat != 0 {
+					break
+				}
+
+				if rebind != nil {
+					if rebind.KeyDown(t.Keysym.Sym) {
+						rebind = nil
+						if err := keymap.Save(settings); err != nil {
+							log.Println(err)
+						}
+					}
+				} else if t.Keysym.Sym == rebindKey && foo.Step() == tetris.Menu {
+					rebind = input.NewRebind(keymap)
+				} else {
+					keymap.KeyDown(controls, t.Keysym.Sym)
 				}
 			case *sdl.KeyUpEvent:
-				foo.BufferCommand(0)
+				keymap.KeyUp(controls, t.Keysym.Sym)
+			case *sdl.ControllerDeviceEvent:
+				if t.Type == sdl.CONTROLLERDEVICEADDED {
+					pads.Added(int(t.Which))
+				} else if t.Type == sdl.CONTROLLERDEVICEREMOVED {
+					pads.Removed(controls, t.Which)
+				}
+			case *sdl.ControllerButtonEvent:
+				pads.Button(controls, t.Which, t.Button, t.State == sdl.PRESSED)
+			case *sdl.ControllerAxisEvent:
+				pads.Axis(controls, t.Which, t.Axis, t.Value)
 			case *sdl.QuitEvent:
 				running = false
 			}
@@ -86,7 +163,10 @@ func main() {
 		renderer.Clear()
 
 		// Draw game
-		foo.Draw(renderer)
+		view.Draw(foo)
+		if rebind != nil {
+			view.DrawMessage(foo, rebind.Prompt())
+		}
 
 		renderer.Present()
 
@@ -96,6 +176,8 @@ func main() {
 	}
 
 	// Clean Up
+	pads.Close()
+	sound.Close()
 	sdl.Quit()
 	sdlaudio.Quit()
 }
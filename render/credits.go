@@ -0,0 +1,46 @@
+package render
+
+import "gitlab.com/rangerdanger/tetris/tetris"
+
+var staffRoll = []string{
+	"STAFF",
+	"",
+	"GAME DESIGN",
+	"RANGERDANGER",
+	"",
+	"PROGRAM",
+	"RANGERDANGER",
+	"",
+	"RULES AFTER",
+	"TETRIS THE",
+	"GRAND MASTER",
+	"",
+	"LIBRARIES",
+	"GO-SDL2",
+	"SDLAUDIO",
+	"",
+	"THANK YOU",
+	"FOR PLAYING",
+}
+
+// Scroll the staff roll up across the playfield as the credits progress
+func (r *Renderer) drawCredits(g *tetris.Game) {
+	if r.font == nil {
+		return
+	}
+
+	b := g.Board()
+	width := int32(b.Width()) * r.cellSize
+	height := int32(b.Height()) * r.cellSize
+	lineHeight := r.font.Height(hudScale) + hudScale*4
+	total := lineHeight * int32(len(staffRoll))
+
+	y := r.y + height - int32(g.CreditsProgress()*float64(height+total))
+	for _, line := range staffRoll {
+		if y >= r.y && y+lineHeight <= r.y+height {
+			x := r.x + (width-r.font.Width(line, hudScale))/2
+			r.font.Draw(r.r, line, x, y, hudScale, hudColor)
+		}
+		y += lineHeight
+	}
+}
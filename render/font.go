@@ -0,0 +1,123 @@
+package render
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"gitlab.com/rangerdanger/tetris/tetris"
+)
+
+// Font is a bitmap font where every glyph is a grid of set pixels
+type Font struct {
+	width  int
+	height int
+	glyphs map[rune][][]bool
+}
+
+// LoadFont reads a bitmap font from a text file. Lines before the first
+// glyph are comments, each glyph starts with a "glyph" line naming the
+// character followed by one row of pixels per line, '#' marking set pixels
+func LoadFont(path string) (*Font, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	f := &Font{glyphs: make(map[rune][][]bool)}
+	var glyph rune
+	var rows [][]bool
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "glyph ") {
+			if rows != nil {
+				f.add(glyph, rows)
+			}
+
+			name := strings.TrimPrefix(line, "glyph ")
+			if name == "space" {
+				glyph = ' '
+			} else {
+				glyph = []rune(name)[0]
+			}
+			rows = [][]bool{}
+			continue
+		}
+
+		// Lines before the first glyph are comments
+		if rows == nil {
+			continue
+		}
+
+		row := make([]bool, len(line))
+		for i, c := range line {
+			row[i] = c == '#'
+		}
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if rows != nil {
+		f.add(glyph, rows)
+	}
+
+	return f, nil
+}
+
+func (f *Font) add(glyph rune, rows [][]bool) {
+	f.glyphs[glyph] = rows
+	if len(rows) > f.height {
+		f.height = len(rows)
+	}
+
+	for _, row := range rows {
+		if len(row) > f.width {
+			f.width = len(row)
+		}
+	}
+}
+
+// Width returns the pixel width of text drawn at scale
+func (f *Font) Width(text string, scale int32) int32 {
+	return int32(len(text)) * int32(f.width+1) * scale
+}
+
+// Height returns the pixel height of a line drawn at scale
+func (f *Font) Height(scale int32) int32 {
+	return int32(f.height) * scale
+}
+
+// Draw renders text with its top left corner at x, y with every
+// font pixel drawn as a square of scale pixels
+func (f *Font) Draw(r *sdl.Renderer, text string, x, y, scale int32, c tetris.Color) {
+	r.SetDrawColor(c.R, c.G, c.B, c.A)
+
+	for _, ch := range text {
+		rows, ok := f.glyphs[unicode.ToUpper(ch)]
+		if ok {
+			for j, row := range rows {
+				for i, set := range row {
+					if set {
+						rect := sdl.Rect{X: x + int32(i)*scale, Y: y + int32(j)*scale, W: scale, H: scale}
+						r.FillRect(&rect)
+					}
+				}
+			}
+		}
+
+		x += int32(f.width+1) * scale
+	}
+}
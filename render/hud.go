@@ -0,0 +1,99 @@
+package render
+
+import (
+	"fmt"
+
+	"github.com/veandco/go-sdl2/sdl"
+
+	"gitlab.com/rangerdanger/tetris/tetris"
+)
+
+// HUD text color and scale
+var hudColor = tetris.Color{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+
+const hudScale int32 = 2
+
+// Draw score, level against the section target, play time, grade
+// and GM eligibility in a column left of the playfield, below the hold box
+func (r *Renderer) drawHUD(g *tetris.Game) {
+	if r.font == nil {
+		return
+	}
+
+	lines := []string{
+		"SCORE", fmt.Sprint(g.Score()),
+		"LEVEL", fmt.Sprintf("%d/%d", g.Level(), g.SectionTarget()),
+		"TIME", g.RunTime(),
+		"GRADE", g.Grade(),
+		"GM", gmStatus(g),
+	}
+
+	x := r.x - 9*r.cellSize
+	y := r.y + 6*r.cellSize
+	for i, line := range lines {
+		r.font.Draw(r.r, line, x, y, hudScale, hudColor)
+		y += r.font.Height(hudScale) + hudScale*2
+
+		// Gap between label and value pairs
+		if i%2 == 1 {
+			y += r.font.Height(hudScale) / 2
+		}
+	}
+}
+
+// Draw the game over banner with the final grade across the playfield,
+// telling a cleared game apart from topping out
+func (r *Renderer) drawGameOver(g *tetris.Game) {
+	if r.font == nil {
+		return
+	}
+
+	title := "GAME OVER"
+	if g.Cleared() {
+		title = "CLEARED"
+	}
+
+	lines := []string{title, "GRADE " + g.Grade(), "GM " + gmStatus(g)}
+	r.drawBanner(g.Board(), lines)
+}
+
+// DrawMessage draws lines of text on a band across the playfield
+func (r *Renderer) DrawMessage(g *tetris.Game, lines []string) {
+	if r.font == nil {
+		return
+	}
+
+	r.drawBanner(g.Board(), lines)
+}
+
+// Draw lines of text centred on a black band across the playfield
+func (r *Renderer) drawBanner(b tetris.Grid, lines []string) {
+	width := int32(b.Width()) * r.cellSize
+	lineHeight := r.font.Height(hudScale) + hudScale*4
+	band := sdl.Rect{
+		X: r.x,
+		Y: r.y + int32(b.Height())*r.cellSize/2 - lineHeight*int32(len(lines))/2 - hudScale*2,
+		W: width,
+		H: lineHeight*int32(len(lines)) + hudScale*4,
+	}
+	r.setColor(tetris.Black)
+	r.r.FillRect(&band)
+
+	y := band.Y + hudScale*4
+	for _, line := range lines {
+		x := r.x + (width-r.font.Width(line, hudScale))/2
+		r.font.Draw(r.r, line, x, y, hudScale, hudColor)
+		y += lineHeight
+	}
+}
+
+// Describe whether the run is still GM eligible
+func gmStatus(g *tetris.Game) string {
+	if g.Grade() == tetris.GMGrade {
+		return "AWARDED"
+	} else if g.GMEligible() {
+		return "ELIGIBLE"
+	}
+
+	return "FAILED"
+}
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+
+	"gitlab.com/rangerdanger/tetris/tetris"
+)
+
+// Renderer draws a tetris game onto an sdl.Renderer
+type Renderer struct {
+	r        *sdl.Renderer
+	font     *Font
+	x        int32
+	y        int32
+	cellSize int32
+}
+
+// New returns a renderer drawing the playfield at x, y with cells
+// of cellSize pixels and text in font. The HUD is skipped if font is nil
+func New(r *sdl.Renderer, font *Font, x, y, cellSize int32) *Renderer {
+	return &Renderer{r: r, font: font, x: x, y: y, cellSize: cellSize}
+}
+
+// Draw renders the board, the ghost and active piece, the preview
+// queue, the hold box, the HUD, the staff roll and the countdown and
+// game over banners
+func (r *Renderer) Draw(g *tetris.Game) {
+	r.drawBoard(g.Board())
+	if g.ShowGhost() {
+		r.drawGhost(g.GhostPiece())
+	}
+	if g.Step() == tetris.Locking {
+		r.drawTetromino(g.ActivePiece())
+	}
+	r.drawPreview(g.Board(), g.Preview())
+
+	if g.Mode().Hold {
+		r.drawHold(g.HoldPiece())
+	}
+
+	r.drawHUD(g)
+
+	if g.InCredits() && !g.Over() {
+		r.drawCredits(g)
+	}
+
+	if text := g.Countdown(); text != "" && r.font != nil {
+		r.drawBanner(g.Board(), []string{text})
+	}
+
+	if g.Over() {
+		r.drawGameOver(g)
+	}
+}
+
+// Returns the pixel rectangle of the cell at column x, row y
+func (r *Renderer) cellRect(x, y int) sdl.Rect {
+	return sdl.Rect{
+		X: r.x + int32(x)*r.cellSize,
+		Y: r.y + int32(y)*r.cellSize,
+		W: r.cellSize,
+		H: r.cellSize,
+	}
+}
+
+func (r *Renderer) setColor(c tetris.Color) {
+	r.r.SetDrawColor(c.R, c.G, c.B, c.A)
+}
+
+// Draw the grid with its visible locked pieces
+func (r *Renderer) drawBoard(b tetris.Grid) {
+	for y := 0; y < b.Height(); y++ {
+		for x := 0; x < b.Width(); x++ {
+			c, _ := b.Cell(x, y)
+			if !b.Visible(x, y) {
+				c = tetris.Black
+			}
+
+			rect := r.cellRect(x, y)
+			r.setColor(c)
+			r.r.FillRect(&rect)
+		}
+	}
+}
+
+// Draw the blocks of a tetromino at its grid position
+func (r *Renderer) drawTetromino(t tetris.Tetromino) {
+	r.drawBlocks(t, r.x, r.y, r.cellSize)
+}
+
+// Draw a translucent outline of the tetromino at its grid position
+func (r *Renderer) drawGhost(t tetris.Tetromino) {
+	c := t.Color()
+	r.r.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+	r.r.SetDrawColor(c.R, c.G, c.B, 0x80)
+	for _, v := range t.Blocks() {
+		rect := r.cellRect(v.X, v.Y)
+		r.r.DrawRect(&rect)
+	}
+	r.r.SetDrawBlendMode(sdl.BLENDMODE_NONE)
+}
+
+// Draw the blocks of a tetromino relative to the pixel origin x, y
+// with cells of size pixels
+func (r *Renderer) drawBlocks(t tetris.Tetromino, x, y, size int32) {
+	r.setColor(t.Color())
+	for _, v := range t.Blocks() {
+		rect := sdl.Rect{X: x + int32(v.X)*size, Y: y + int32(v.Y)*size, W: size, H: size}
+		r.r.FillRect(&rect)
+	}
+}
+
+// Draw the upcoming pieces to the right of the playfield, the next
+// piece at full size and the rest of the queue at half size below it
+func (r *Renderer) drawPreview(b tetris.Grid, queue []tetris.Tetromino) {
+	x := r.x + int32(b.Width()+1)*r.cellSize
+	y := r.y + r.cellSize
+
+	for i, t := range queue {
+		size := r.cellSize
+		if i > 0 {
+			size = r.cellSize / 2
+		}
+
+		r.drawBlocks(t, x, y, size)
+		y += size * 3
+	}
+}
+
+// Draw the held piece in a box to the left of the playfield
+func (r *Renderer) drawHold(t tetris.Tetromino, holding bool) {
+	box := sdl.Rect{X: r.x - 5*r.cellSize, Y: r.y + r.cellSize, W: 4 * r.cellSize, H: 4 * r.cellSize}
+	r.setColor(tetris.Black)
+	r.r.FillRect(&box)
+
+	if holding {
+		r.drawBlocks(t, box.X, box.Y, r.cellSize)
+	}
+}
@@ -0,0 +1,88 @@
+package tetris
+
+import "sort"
+
+// Kicks tried in order when a rotation is blocked, one cell right then
+// one cell left. TGM has no floor kicks
+var arsKicks = []Point{{X: 1, Y: 0}, {X: -1, Y: 0}}
+
+// Block layouts in each orientation, every piece spawning flat side up
+// in orientation 0
+var arsLayouts = map[int32][][4]int{
+	I: {{4, 5, 6, 7}, {2, 6, 10, 14}},
+	J: {{3, 4, 5, 8}, {1, 4, 7, 6}, {3, 6, 7, 8}, {1, 4, 7, 2}},
+	L: {{3, 4, 5, 6}, {1, 4, 7, 0}, {5, 6, 7, 8}, {1, 4, 7, 8}},
+	O: {{5, 6, 9, 10}},
+	S: {{6, 7, 4, 5}, {0, 3, 4, 7}},
+	T: {{3, 4, 5, 7}, {1, 4, 7, 3}, {4, 6, 7, 8}, {1, 4, 7, 5}},
+	Z: {{3, 4, 7, 8}, {4, 7, 5, 2}},
+}
+
+// The Arika Rotation System used by TGM
+type arsSystem struct{}
+
+// Layout returns the ARS box width and block indices
+func (arsSystem) Layout(shape int32, orientation int) (int, [4]int) {
+	return arsBox(shape), arsLayouts[shape][orientation]
+}
+
+// Orientations returns the number of ARS orientations of a shape
+func (arsSystem) Orientations(shape int32) int {
+	return len(arsLayouts[shape])
+}
+
+// SpawnOrientation is always the first orientation
+func (arsSystem) SpawnOrientation(shape int32) int {
+	return 0
+}
+
+// Rotate tries the basic rotation first, then each kick
+func (arsSystem) Rotate(t Tetromino, turns int, blocked func(Point) bool) (Tetromino, bool) {
+	rotated := t.turned(turns)
+
+	if !rotated.collides(blocked) {
+		return rotated, true
+	}
+
+	if !arsCanKick(rotated, blocked) {
+		return rotated, false
+	}
+
+	return firstKick(rotated, arsKicks, blocked)
+}
+
+// I and O are laid out in a 4x4 box, everything else in 3x3
+func arsBox(shape int32) int {
+	if shape == I || shape == O {
+		return 4
+	}
+
+	return 3
+}
+
+// The I piece never kicks. L, J and T can not kick when the first of
+// the rotated piece's blocked cells, scanning its box left to right and
+// top to bottom, lies in the centre column of the box
+func arsCanKick(rotated Tetromino, blocked func(Point) bool) bool {
+	switch rotated.shape {
+	case I:
+		return false
+	case L, J, T:
+		blocks := make([]Point, len(rotated.blocks))
+		copy(blocks, rotated.blocks[:])
+		sort.Slice(blocks, func(i, j int) bool {
+			if blocks[i].Y != blocks[j].Y {
+				return blocks[i].Y < blocks[j].Y
+			}
+			return blocks[i].X < blocks[j].X
+		})
+
+		for _, v := range blocks {
+			if blocked(v) {
+				return v.X != rotated.x+1
+			}
+		}
+	}
+
+	return true
+}
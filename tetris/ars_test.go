@@ -0,0 +1,69 @@
+package tetris
+
+import "testing"
+
+func TestARSRotate(t *testing.T) {
+	// Each case places a piece in some orientation with its box at x, y
+	// on a board holding the stack cells, then turns it
+	tests := []struct {
+		name        string
+		piece       func() Tetromino
+		orientation int
+		x, y        int
+		stack       []Point
+		turns       int
+		ok          bool
+		kickedX     int
+	}{
+		{"basic rotation", TTetromino, 0, 3, 5, nil, 1, true, 3},
+
+		// Upright T against a wall rotating flat pokes through it
+		{"right kick off the left wall", TTetromino, 3, -1, 5, nil, 1, true, 0},
+		{"left kick off the right wall", TTetromino, 1, 8, 5, nil, -1, true, 7},
+		{"right kick off the stack", TTetromino, 3, 3, 5, []Point{{3, 6}}, 1, true, 4},
+		{"no kick when both sides are blocked", TTetromino, 3, 3, 5, []Point{{3, 6}, {6, 6}}, 1, false, 3},
+
+		{"I never kicks", ITetromino, 1, 7, 5, nil, 1, false, 7},
+
+		// The first blocked cell is in the centre column
+		{"T centre column", TTetromino, 0, 3, 5, []Point{{4, 5}}, 1, false, 3},
+		{"L centre column", LTetromino, 0, 3, 5, []Point{{4, 7}}, 1, false, 3},
+		{"J centre column", JTetromino, 0, 3, 5, []Point{{4, 7}}, 1, false, 3},
+		{"J side column", JTetromino, 0, 3, 5, []Point{{3, 7}}, 1, true, 4},
+
+		// Cells are scanned a row at a time, so the centre cell above
+		// decides even though the blocked side cell is further left
+		{"J centre scanned by row", JTetromino, 0, 3, 5, []Point{{4, 5}, {3, 7}}, 1, false, 3},
+	}
+
+	for _, test := range tests {
+		g := NewGame(TGM1Mode, 1, nil)
+		g.Init()
+		g.Start()
+		for _, v := range test.stack {
+			g.board.fill(v.X, v.Y, Red)
+		}
+
+		piece := test.piece().turned(test.orientation)
+		piece.move(test.x, test.y)
+		if g.collision(piece) {
+			t.Fatalf("%s: piece %v placed on the stack", test.name, piece.Blocks())
+		}
+		g.activePiece = piece
+
+		g.tryRotate(test.turns)
+		rotated := g.activePiece.orientation != piece.orientation
+		if rotated != test.ok {
+			t.Errorf("%s: rotated %v, want %v", test.name, rotated, test.ok)
+			continue
+		}
+
+		if g.activePiece.x != test.kickedX {
+			t.Errorf("%s: piece at column %d, want %d", test.name, g.activePiece.x, test.kickedX)
+		}
+
+		if g.collision(g.activePiece) {
+			t.Errorf("%s: rotated into the stack %v", test.name, g.activePiece.Blocks())
+		}
+	}
+}
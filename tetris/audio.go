@@ -0,0 +1,62 @@
+package tetris
+
+// Audio plays the game's music tracks and sound effects by name
+type Audio interface {
+	LoadMusic(name, path string) error
+	LoadEffect(name, path string) error
+
+	// PlayMusic plays a track loops times, -1 looping forever
+	PlayMusic(track string, loops int) error
+
+	FadeOutMusic(ms int)
+	PlayEffect(name string) error
+}
+
+// NullAudio plays nothing, for running the game headless
+type NullAudio struct{}
+
+// LoadMusic does nothing
+func (NullAudio) LoadMusic(name, path string) error { return nil }
+
+// LoadEffect does nothing
+func (NullAudio) LoadEffect(name, path string) error { return nil }
+
+// PlayMusic does nothing
+func (NullAudio) PlayMusic(track string, loops int) error { return nil }
+
+// FadeOutMusic does nothing
+func (NullAudio) FadeOutMusic(ms int) {}
+
+// PlayEffect does nothing
+func (NullAudio) PlayEffect(name string) error { return nil }
+
+// RecordingAudio plays nothing but records the tracks and effects the
+// game triggers, so tests can assert on them
+type RecordingAudio struct {
+	Tracks  []string
+	Effects []string
+	Fades   int
+}
+
+// LoadMusic does nothing
+func (a *RecordingAudio) LoadMusic(name, path string) error { return nil }
+
+// LoadEffect does nothing
+func (a *RecordingAudio) LoadEffect(name, path string) error { return nil }
+
+// PlayMusic records the track
+func (a *RecordingAudio) PlayMusic(track string, loops int) error {
+	a.Tracks = append(a.Tracks, track)
+	return nil
+}
+
+// FadeOutMusic counts the fade
+func (a *RecordingAudio) FadeOutMusic(ms int) {
+	a.Fades++
+}
+
+// PlayEffect records the effect
+func (a *RecordingAudio) PlayEffect(name string) error {
+	a.Effects = append(a.Effects, name)
+	return nil
+}
@@ -0,0 +1,41 @@
+package tetris
+
+import "math/rand"
+
+// BagRandomizer deals every piece of a shuffled bag before refilling it,
+// as used by guideline games
+type BagRandomizer struct {
+	rng  *rand.Rand
+	size int
+	bag  []int32
+}
+
+// NewBagRandomizer returns a randomizer whose bag holds the given
+// number of each piece, 1 for a 7-bag and 2 for a 14-bag
+func NewBagRandomizer(src rand.Source, copies int) *BagRandomizer {
+	return &BagRandomizer{rng: rand.New(src), size: copies * int(tetrominos)}
+}
+
+// Next gets the next Tetromino from the bag
+func (r *BagRandomizer) Next() Tetromino {
+	if len(r.bag) == 0 {
+		r.fill()
+	}
+
+	tS := r.bag[0]
+	r.bag = r.bag[1:]
+
+	return generateTetronimo(tS)
+}
+
+// Refill the bag and shuffle it
+func (r *BagRandomizer) fill() {
+	r.bag = make([]int32, r.size)
+	for i := range r.bag {
+		r.bag[i] = int32(i) % tetrominos
+	}
+
+	r.rng.Shuffle(len(r.bag), func(i, j int) {
+		r.bag[i], r.bag[j] = r.bag[j], r.bag[i]
+	})
+}
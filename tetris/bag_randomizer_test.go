@@ -0,0 +1,27 @@
+package tetris
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBagRandomizer(t *testing.T) {
+	for _, copies := range []int{1, 2} {
+		r := NewBagRandomizer(rand.NewSource(1), copies)
+		size := copies * int(tetrominos)
+
+		// Every bag holds each piece copies times
+		for bag := 0; bag < 10000; bag++ {
+			var counts [tetrominos]int
+			for i := 0; i < size; i++ {
+				counts[r.Next().Shape()]++
+			}
+
+			for s, n := range counts {
+				if n != copies {
+					t.Fatalf("%d-bag %d: shape %d dealt %d times, want %d", size, bag, s, n, copies)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package tetris
+
+// MaxLevel is the level which ends the game and starts the staff roll
+const MaxLevel int = 999
+
+// Number of frames the staff roll lasts
+const creditsDuration int = 3238
+
+// InCredits returns true while the staff roll is playing
+func (g Game) InCredits() bool {
+	return g.credits
+}
+
+// CreditsProgress returns how far through the staff roll the game is,
+// from 0 at its start to 1 at its end
+func (g Game) CreditsProgress() float64 {
+	return float64(g.creditsFrames) / float64(creditsDuration)
+}
+
+// Cleared returns true once the staff roll has finished, until the
+// menu is shown again
+func (g Game) Cleared() bool {
+	return g.step == Cleared || g.step == Transition && g.lastStep == Cleared
+}
+
+// Start the staff roll on a cleared board once the final level is reached.
+// Players awarded GM play the roll with locked blocks invisible
+func (g *Game) startCredits() {
+	if g.credits || g.level < MaxLevel {
+		return
+	}
+
+	g.level = MaxLevel
+	g.credits, g.creditsFrames = true, 0
+	g.board = NewGrid(gXLength, gYLength)
+	if g.grade == GMGrade {
+		g.board.SetVisibility(BoardInvisible, 0)
+	} else {
+		g.board.SetVisibility(g.mode.Visibility, g.mode.FadeFrames)
+	}
+	g.emit(Event{Type: CreditsStart})
+}
+
+// Advance the staff roll, ending the game as cleared once it has run its course
+func (g *Game) updateCredits() {
+	if !g.credits {
+		return
+	}
+
+	g.creditsFrames++
+	if g.creditsFrames >= creditsDuration {
+		g.step = Cleared
+	}
+}
@@ -0,0 +1,36 @@
+package tetris
+
+// Game events
+const (
+	_ = iota
+	GradeUp
+	CreditsStart
+	MusicPlay
+	MusicFadeOut
+	Shift
+	Rotate
+	Lock
+	LineClear
+	SectionUp
+	NextPiece
+)
+
+// Event is something that happened during a frame which the audio
+// and rendering layers may react to
+type Event struct {
+	Type  int
+	Grade string
+	Track string
+	Lines int
+	Piece int32 // the upcoming piece for NextPiece
+	Turns int   // quarter turns clockwise for Rotate, 2 for a 180
+}
+
+// Events returns the events emitted during the last processed frame
+func (g Game) Events() []Event {
+	return g.events
+}
+
+func (g *Game) emit(e Event) {
+	g.events = append(g.events, e)
+}
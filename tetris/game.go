@@ -3,29 +3,28 @@ package tetris
 import (
 	"fmt"
 	"log"
-	"math/rand"
+	"sort"
 	"time"
-
-	"github.com/veandco/go-sdl2/sdl"
-	"gitlab.com/rangerdanger/sdlaudio"
 )
 
+// FPS is the number of frames processed each second
+const FPS = 60
+
 // Board presets
 const gXLength int = 10
 const gYLength int = 20
-const gSize int32 = 20
-const gStartX int32 = 600/2 - (int32(gXLength)*gSize)/2
-const gStartY int32 = 0
 
 // Game steps
 const (
 	Menu = iota
+	Ready
 	Locking
 	Clearing
 	ClearDelay
 	Spawning
 	Transition
 	GameOver
+	Cleared
 )
 
 // Input commands
@@ -35,18 +34,29 @@ const (
 	ShiftRight
 	RotateClockwise
 	RotateCounterClockwise
+	Rotate180
 	ManualDrop
+	Hold
 	Start
 )
 
 type Game struct {
-	start       time.Time
+	frames      int
+	mode        Mode
+	seed        int64
+	fixedSeed   int64
+	audio       Audio
+	randomizer  Randomizer
+	rotation    RotationSystem
 	activePiece Tetromino
-	nextPiece   Tetromino
+	queue       []Tetromino
 	holdPiece   Tetromino
+	holding     bool
+	holdUsed    bool
 	board       Grid
-	command     int32
-	lastCommand int32
+	keys        Input
+	taps        Input
+	input       InputState
 
 	areDelay, areFrames     int
 	dasDelay, dasFrames     int
@@ -60,16 +70,54 @@ type Game struct {
 
 	gravFrames float64
 	gravity    float64
+	gravRows   int // rows left to fall this frame at 1G and above
 
 	level int
 	score int
 	combo int
 	bravo int
+
+	lastStep         int
+	transitionFrames int
+	readyFrames      int
+	readyRotation    int32
+	grade            string
+	events           []Event
+
+	gmEligible   bool
+	gmCheckpoint int
+
+	credits       bool
+	creditsFrames int
+
+	musicSection int
+	musicFading  bool
 }
 
-// NewGame returns a new game struct
-func NewGame() *Game {
-	return new(Game)
+// NewGame returns a new game struct played under mode whose pieces
+// are dealt from a randomizer seeded with seed and whose music and
+// sound effects are played through audio, nil playing nothing. Every
+// game started replays the same pieces from seed, a seed of 0 giving
+// each game a new seed from the clock instead
+func NewGame(mode Mode, seed int64, audio Audio) *Game {
+	g := new(Game)
+	g.mode = mode
+	g.seed, g.fixedSeed = seed, seed
+	g.audio = audio
+	if g.audio == nil {
+		g.audio = NullAudio{}
+	}
+	return g
+}
+
+// Mode returns the rules the game is played under
+func (g Game) Mode() Mode {
+	return g.mode
+}
+
+// Seed returns the seed the current game's randomizer was created from
+func (g Game) Seed() int64 {
+	return g.seed
 }
 
 // Step returns game step
@@ -79,15 +127,23 @@ func (g Game) Step() int {
 
 // Init sets up the games variables
 func (g *Game) Init() {
-	ResetTGMRandomizer()
-	g.loadMusic()
-	sdlaudio.PlayMusic("menu", -1)
+	g.board = NewGrid(gXLength, gYLength)
+
+	rs, err := NewRotationSystem(g.mode.Rotation)
+	if err != nil {
+		log.Println(err)
+		rs = ARS
+	}
+	g.rotation = rs
+
+	g.loadAudio()
+	g.playMusic("menu")
 
 	g.level = 0
 	g.score = 0
 	g.combo = 1
 	g.bravo = 1
-	g.command = 0
+	g.input = InputState{}
 
 	g.areDelay, g.areFrames = 30, 0
 	g.dasDelay, g.dasFrames = 14, 0
@@ -100,112 +156,243 @@ func (g *Game) Init() {
 
 }
 
-// Start initalizes game
+// Start initalizes game and begins the countdown to the first piece
 func (g *Game) Start() {
-	g.board = NewGrid(gStartX, gStartY, gSize, gXLength, gYLength)
-	g.step = Locking
-	sdlaudio.PlayMusic("easy", -1)
-	g.activePiece, g.nextPiece = NextTGMRandomizer(), NextTGMRandomizer()
-	g.SpawnTetromino(&g.activePiece)
+	g.board = NewGrid(gXLength, gYLength)
+	g.board.SetVisibility(g.mode.Visibility, g.mode.FadeFrames)
+	g.frames = 0
+	g.level, g.score, g.combo, g.bravo = 0, 0, 1, 1
+	g.grade = gradeFor(g.score)
+	g.gmEligible, g.gmCheckpoint = true, 0
+	g.credits, g.creditsFrames = false, 0
+	g.holding, g.holdUsed = false, false
+	g.dasFrames, g.readyFrames, g.readyRotation = 0, 0, 0
+	g.step = Ready
+	g.startMusic()
+
+	// Every game deals from a fresh randomizer so its seed reproduces it
+	g.seed = g.fixedSeed
+	if g.seed == 0 {
+		g.seed = time.Now().UnixNano()
+	}
+
+	r, err := NewRandomizer(g.mode.Randomizer, g.seed)
+	if err != nil {
+		log.Println(err)
+		r, _ = NewRandomizer(TGM1Mode.Randomizer, g.seed)
+	}
+	g.randomizer = r
+	g.fillQueue()
+}
+
+// Time returns the time spent playing, counted in frames at FPS
+func (g Game) Time() time.Duration {
+	return time.Duration(g.frames) * time.Second / FPS
+}
+
+// RunTime returns the play time formatted as mm:ss:cc
+func (g Game) RunTime() string {
+	t := g.Time()
+	return fmt.Sprintf("%02d:%02d:%02d", int(t.Minutes()), int(t.Seconds())%60, int(t.Milliseconds()/10)%100)
+}
+
+// Score returns the player's score
+func (g Game) Score() int {
+	return g.score
+}
+
+// Level returns the current level
+func (g Game) Level() int {
+	return g.level
 }
 
-func (g *Game) RunTime() string {
-	since := time.Since(g.start)
-	return fmt.Sprintf("%02f:%02f:%02f", since.Minutes(), since.Seconds(), since.Seconds()/1000.0)
+// SectionTarget returns the level ending the current section,
+// the next hundred or 999 in the final section
+func (g Game) SectionTarget() int {
+	target := (g.level/100 + 1) * 100
+	if target > 900 {
+		return 999
+	}
+
+	return target
+}
+
+// Grade returns the highest grade reached by the player's score
+func (g Game) Grade() string {
+	return g.grade
 }
 
-// BufferCommand sets active command
-func (g *Game) BufferCommand(command int32) {
-	g.command = command
+// Over returns true once the game has ended, either topping out or
+// clearing the game, until the menu is shown again
+func (g Game) Over() bool {
+	return g.step == GameOver || g.step == Cleared ||
+		g.step == Transition && (g.lastStep == GameOver || g.lastStep == Cleared)
+}
+
+// Returns the grade whose score threshold is the highest reached by score
+func gradeFor(score int) string {
+	grade := ""
+	best := -1
+	for k, v := range tgmGrading {
+		if score >= v && v > best {
+			grade, best = k, v
+		}
+	}
+
+	return grade
+}
+
+// Update the grade from the score, emitting an event on grade up
+func (g *Game) updateGrade() {
+	if g.grade == GMGrade {
+		return
+	}
+
+	if grade := gradeFor(g.score); tgmGrading[grade] > tgmGrading[g.grade] {
+		g.grade = grade
+		g.emit(Event{Type: GradeUp, Grade: grade})
+	}
+}
+
+// Deal a fresh queue of upcoming pieces, one is always kept
+// even when the mode shows no preview
+func (g *Game) fillQueue() {
+	g.queue = make([]Tetromino, g.mode.previews())
+	if len(g.queue) == 0 {
+		g.queue = make([]Tetromino, 1)
+	}
+
+	for i := range g.queue {
+		g.queue[i] = g.randomizer.Next().withSystem(g.rotation)
+	}
+}
+
+// Take the piece at the front of the queue, dealing a new one onto the back
+func (g *Game) nextTetromino() Tetromino {
+	t := g.queue[0]
+	copy(g.queue, g.queue[1:])
+	g.queue[len(g.queue)-1] = g.randomizer.Next().withSystem(g.rotation)
+	return t
+}
+
+// Preview returns the upcoming pieces shown to the player, next first
+func (g Game) Preview() []Tetromino {
+	n := g.mode.previews()
+	if n > len(g.queue) {
+		n = len(g.queue)
+	}
+
+	preview := make([]Tetromino, n)
+	copy(preview, g.queue)
+	return preview
 }
 
 // Increment the level counter
 func (g *Game) nextLevelRequiresClear() bool {
-	if g.level+1%100 == 0 || g.level == 998 {
+	if (g.level+1)%100 == 0 || g.level >= MaxLevel-1 {
 		return true
 	}
 
 	return false
 }
 
-// LoadMusic passes back the map of audio assets to be loaded
-func (g *Game) loadMusic() error {
-	for k, v := range tgmAudio {
-		if err := sdlaudio.LoadMusic(k, v); err != nil {
-			log.Println(err)
-			return err
-		}
-	}
+// Frames spent transitioning out of each step
+var transitionDelays = map[int]int{
+	Menu:     120,
+	GameOver: 300,
+	Cleared:  300,
+}
 
-	return nil
+// Tracks played over the transition out of each step
+var transitionTracks = map[int]string{
+	Menu:     "start",
+	GameOver: "gameOver",
+	Cleared:  "gameOver",
 }
 
-var lastStep int
+// Begin a transition out of step which lasts a fixed number of frames,
+// its track only decorates it and the game moves on whether it plays or not
+func (g *Game) beginTransition(step int) {
+	g.lastStep, g.step = step, Transition
+	g.transitionFrames = 0
+
+	track := transitionTracks[step]
+	if err := g.audio.PlayMusic(track, 1); err != nil {
+		log.Printf("music %q: %v\n", track, err)
+	}
+}
 
 // ProcessFrame runs the game logic for a frame
 func (g *Game) ProcessFrame() {
+	g.events = g.events[:0]
+	g.readInput()
+
 	switch g.step {
 	case Menu:
-		if g.command == Start {
-			g.step = Transition
-			lastStep = Menu
+		if g.input.Pressed.Has(Start) {
+			g.beginTransition(Menu)
 		}
 	case Transition:
-		var track string
-		if lastStep == Menu {
-			track = "start"
-		} else if lastStep == GameOver {
-			track = "gameOver"
-		}
-
-		if b, err := sdlaudio.PlayMusicOS(track); b {
-			if lastStep == Menu {
+		g.transitionFrames++
+		if g.transitionFrames >= transitionDelays[g.lastStep] {
+			if g.lastStep == Menu {
 				g.Start()
-				g.step = Locking
-			} else if lastStep == GameOver {
-				sdlaudio.PlayMusic("menu", -1)
+			} else if g.lastStep == GameOver || g.lastStep == Cleared {
+				g.playMusic("menu")
 				g.step = Menu
 			}
-
-		} else if err != nil {
-			panic(err)
 		}
-	case GameOver:
-		g.step = Transition
-		lastStep = GameOver
+	case Ready:
+		// The frame the countdown spawns the first piece on is its
+		// spawn frame, the piece is only played from the next frame
+		g.processReady()
+		g.playAudio()
+		return
+	case GameOver, Cleared:
+		g.beginTransition(g.step)
 	}
 
-	if g.step != Menu && g.step != Transition {
-		g.doGravity()
+	if g.step != Menu && g.step != Transition && g.step != Ready {
+		// The clock stops for the staff roll
+		if !g.credits {
+			g.frames++
+		}
+
+		g.board.tick()
+		if g.step == Locking {
+			g.doGravity()
+		}
 		g.soft = false
 
-		if g.command == ShiftLeft || g.command == ShiftRight {
-			if !g.dasLocked() {
-				g.dasFrames++
-			}
+		// Every held command is handled on the same frame, hold first,
+		// then rotation, shifting and dropping. Outside of Locking there
+		// is no piece in play, inputs only charge DAS or are held for
+		// the next spawn
+		if g.step == Locking && g.input.Pressed.Has(Hold) {
+			g.tryHold()
+		}
 
-			if g.dasFrames == 1 || g.dasFrames >= g.dasDelay {
-				if g.command == ShiftLeft {
-					g.tryShift(false)
-				} else {
-					g.tryShift(true)
+		if g.step == Locking {
+			for _, c := range rotationCommands {
+				if g.input.Pressed.Has(c) {
+					g.tryRotate(rotationTurns(c))
 				}
 			}
+		}
 
-		} else {
-			if !g.dasLocked() {
-				g.dasFrames = 0
-			}
+		shift := shiftDirection(g.input.Held)
+		if !g.dasLocked() {
+			g.chargeDAS()
+		}
 
-			if g.command == RotateClockwise && g.lastCommand != RotateClockwise {
-				g.tryRotate(true)
-			} else if g.command == RotateCounterClockwise && g.lastCommand != RotateCounterClockwise {
-				g.tryRotate(false)
-			} else if g.command == ManualDrop {
-				g.softFrames++
-				g.soft = true
-				g.tryDrop()
-			}
+		if g.step == Locking && shift != 0 && (g.dasFrames == 1 || g.dasFrames >= g.dasDelay) {
+			g.tryShift(shift > 0)
+		}
+
+		if g.step == Locking && g.input.Held.Has(ManualDrop) {
+			g.softFrames++
+			g.soft = true
+			g.tryDrop()
 		}
 
 		// State machine
@@ -214,12 +401,14 @@ func (g *Game) ProcessFrame() {
 			g.activeFrames++
 			if g.checkLock() {
 
-				// Check we aren't out of bounds
-				if g.activePiece.Above(g.board.Y()) {
+				// Check we aren't out of bounds, topping out
+				// during the staff roll still clears the game
+				if g.activePiece.Above(0) && g.credits {
+					g.step = Cleared
+				} else if g.activePiece.Above(0) {
 					g.step = GameOver
 				} else {
 					g.activeFrames = 0
-					g.activePiece, g.nextPiece = g.nextPiece, NextTGMRandomizer()
 					g.step = Clearing
 				}
 			}
@@ -240,13 +429,39 @@ func (g *Game) ProcessFrame() {
 			if g.areFrames >= g.areDelay {
 				g.areFrames = 0
 				g.softFrames = 0
+
+				// The queue moves on as the piece spawns, the preview
+				// showing it through line clear delay and ARE
+				g.activePiece = g.nextTetromino()
 				g.SpawnTetromino(&g.activePiece)
+				g.initialRotation(g.input.heldRotation())
+				g.spawnGravity()
 				g.step = Locking
 			}
 		}
+
+		if g.step != GameOver {
+			g.checkGM()
+			g.directMusic()
+			g.startCredits()
+			g.updateCredits()
+		}
+	}
+
+	g.playAudio()
+}
+
+// Charge DAS while a shift is held, starting over when it is released
+// or changes direction
+func (g *Game) chargeDAS() {
+	shift := shiftDirection(g.input.Held)
+	if shift == 0 || shift != shiftDirection(g.input.lastHeld()) {
+		g.dasFrames = 0
 	}
 
-	g.lastCommand = g.command
+	if shift != 0 {
+		g.dasFrames++
+	}
 }
 
 // The players DAS charge is unmodified during line clear delay,
@@ -262,19 +477,40 @@ func (g Game) dasLocked() bool {
 	return false
 }
 
-// Draw renders the game using an sdl.Renderer
-func (g Game) Draw(r *sdl.Renderer) {
-	g.board.Draw(r)
-	g.activePiece.Draw(r)
+// Board returns the playfield with its locked cells
+func (g Game) Board() Grid {
+	return g.board
 }
 
-func (g *Game) doGravity() {
-	// Get current gravity according to game level
-	for i, v := range tgmGravity {
-		if i > g.level {
+// ActivePiece returns the tetromino currently under player control
+func (g Game) ActivePiece() Tetromino {
+	return g.activePiece
+}
+
+// Returns the gravity in rows per frame for the current level
+func (g *Game) levelGravity() float64 {
+	gravity := 0.0
+	for _, level := range tgmGravityLevels {
+		if level > g.level {
 			break
 		}
-		g.gravity = v / 256
+		gravity = tgmGravity[level] / 256
+	}
+
+	return gravity
+}
+
+// Gravity below 1G builds up over frames, at 1G and above the piece
+// falls as far as it can up to G rows straight away which at 20G
+// reaches the floor
+func (g *Game) doGravity() {
+	g.gravity = g.levelGravity()
+
+	if g.gravity >= 1 {
+		g.gravFrames = 0
+		g.gravRows = int(g.gravity)
+		g.fall()
+		return
 	}
 
 	g.gravFrames += g.gravity
@@ -285,14 +521,81 @@ func (g *Game) doGravity() {
 	}
 }
 
-// Attempts to drop piece if valid
-func (g *Game) tryDrop() {
+// Drop the active piece as far as it can go on the rows left to fall
+// this frame, stopping as it lands
+func (g *Game) fall() {
+	for g.gravRows > 0 && g.tryDrop() {
+		g.gravRows--
+	}
+}
+
+// A piece spawns after the frame's gravity has been applied, so at 1G
+// and above it falls its G rows as soon as it spawns
+func (g *Game) spawnGravity() {
+	g.gravity = g.levelGravity()
+	if g.gravity >= 1 {
+		g.gravRows = int(g.gravity)
+		g.fall()
+	}
+}
+
+// At 1G and above a piece which shifts or rotates off the stack keeps
+// falling as far as the frame's gravity allows, never more than G rows
+// in a frame, so at 20G pieces slide along and climb over the stack
+func (g *Game) highGravity() {
+	if g.gravity >= 1 {
+		g.fall()
+	}
+}
+
+// Returns the tetromino dropped as far as it can go without colliding
+func (g *Game) landing(t Tetromino) Tetromino {
+	testPiece := t
+	testPiece.Drop()
+
+	for !g.collision(testPiece) {
+		t = testPiece
+		testPiece.Drop()
+	}
+
+	return t
+}
+
+// GhostPiece returns the active piece at its landing position
+func (g *Game) GhostPiece() Tetromino {
+	return g.landing(g.activePiece)
+}
+
+// ShowGhost returns true if the mode's ghost policy shows the
+// ghost piece at the current level
+func (g Game) ShowGhost() bool {
+	if g.step != Locking {
+		return false
+	}
+
+	switch g.mode.Ghost {
+	case GhostAlways:
+		return true
+	case GhostBeginner:
+		return g.level <= 100
+	}
+
+	return false
+}
+
+// Attempts to drop piece if valid, returning true if it moved. A piece
+// moving down a row starts its lock delay over
+func (g *Game) tryDrop() bool {
 	testPiece := g.activePiece
 	testPiece.Drop()
 
-	if !g.collision(testPiece) {
-		g.activePiece.Drop()
+	if g.collision(testPiece) {
+		return false
 	}
+
+	g.activePiece = testPiece
+	g.lockFrames = 0
+	return true
 }
 
 func (g *Game) checkLock() bool {
@@ -311,15 +614,9 @@ func (g *Game) checkLock() bool {
 
 	if g.lockFrames >= g.lockDelay {
 		for _, v := range g.activePiece.blocks {
-			for _, row := range g.board.cells {
-				for col := range row {
-					if v.Equals(&row[col].rect) {
-						row[col].occupied = true
-						row[col].color = g.activePiece.color
-					}
-				}
-			}
+			g.board.fill(v.X, v.Y, g.activePiece.color)
 		}
+		g.emit(Event{Type: Lock})
 
 		return true
 	}
@@ -344,13 +641,21 @@ func (g *Game) checkClear() bool {
 		} else {
 			g.bravo = 1
 		}
+		g.emit(Event{Type: LineClear, Lines: cleared})
 		g.combo += (2 * cleared) - 2
 		g.score += (roof(g.level+cleared, 4) + g.softFrames) * cleared * ((2 * cleared) - 1) * g.combo * g.bravo
+		g.updateGrade()
 	} else {
 		g.combo = 1
 	}
 
-	g.level += cleared // level up
+	if !g.credits {
+		section := g.level / 100
+		g.level += cleared // level up
+		if g.level/100 > section {
+			g.emit(Event{Type: SectionUp})
+		}
+	}
 
 	return cleared > 0
 }
@@ -377,161 +682,130 @@ func (g *Game) checkLineClear(row int) bool {
 
 // Drop lines above cleared line
 func (g *Game) clearLine(row int) {
-	g.board.cells[row] = g.board.createRow(row)
+	g.board.cells[row] = g.board.createRow()
 
 	// Drop all occupied spaces by 1
 	for i := row; i > 0; i-- {
-		for col := range g.board.cells[i] {
-			g.board.cells[i][col].occupied = g.board.cells[i-1][col].occupied
-			g.board.cells[i][col].color = g.board.cells[i-1][col].color
-		}
+		copy(g.board.cells[i], g.board.cells[i-1])
 	}
-}
-
-// CollisionRects returns the sdl.Rect elements from lockedPieces
-func (g Game) terminoIntersection(t Tetromino, r sdl.Rect) bool {
-	for _, v := range t.blocks {
-		if v.HasIntersection(&r) {
-			return true
-		}
-	}
-
-	return false
+	g.board.cells[0] = g.board.createRow()
 }
 
 // Collision checks if a tetromino is colliding with the following
 // 1. Locked pieces
 // 2. Board edges
 func (g *Game) collision(t Tetromino) bool {
-	// Check tetromino outside the board
 	for _, v := range t.blocks {
-		if v.X < g.board.X() || v.X >= g.board.X()+g.board.PixelWidth() {
-			return true
-		} else if v.Y >= g.board.Y()+g.board.PixelHeight() {
+		if g.blocked(v) {
 			return true
 		}
 	}
 
-	// Check collision with any occupied spaces
-	for _, row := range g.board.cells {
-		for _, col := range row {
-			if col.occupied && g.terminoIntersection(t, col.rect) {
-				return true
-			}
-		}
+	return false
+}
+
+// Returns true if a block can not sit at p, either outside the board
+// edges or floor, or on an occupied space. Rows above the board are open
+func (g *Game) blocked(p Point) bool {
+	if p.X < 0 || p.X >= g.board.Width() {
+		return true
+	} else if p.Y >= g.board.Height() {
+		return true
 	}
 
-	return false
+	return g.board.Occupied(p.X, p.Y)
 }
 
 // SpawnTetromino on the grid
 func (g *Game) SpawnTetromino(t *Tetromino) {
-	t.Resize(g.board.cellSize)
-	t.move(g.board.spawnX, g.board.spawnY)
-
-	if g.collision(*t) {
-		t.move(g.board.altSpawnX, g.board.altSpawnY)
-	}
+	g.placeAtSpawn(t)
+	g.holdUsed = false
+	g.emit(Event{Type: NextPiece, Piece: g.queue[0].shape})
 
 	g.activeFrames = 1
-	if !g.nextLevelRequiresClear() {
+	if !g.credits && !g.nextLevelRequiresClear() {
 		g.level++
 	}
 }
 
-// TryShift will check and perform valid shift
-func (g *Game) tryShift(right bool) {
-	testPiece := g.activePiece
-	if right {
-		testPiece.ShiftRight()
-	} else {
-		testPiece.ShiftLeft()
+// Initial Rotation System, a rotation held as the piece spawns rotates
+// it straight away in modes which allow it
+func (g *Game) initialRotation(command int32) {
+	if g.mode.IRS {
+		g.spawnRotation(command)
+	}
+}
+
+// Rotate the piece which just spawned by a rotation command, leaving it
+// unrotated if the rotation collides
+func (g *Game) spawnRotation(command int32) {
+	turns := rotationTurns(command)
+	if turns == 0 || !g.activePiece.reorients(turns) {
+		return
 	}
 
+	testPiece := g.activePiece.turned(turns)
 	if !g.collision(testPiece) {
-		if right {
-			g.activePiece.ShiftRight()
-		} else {
-			g.activePiece.ShiftLeft()
-		}
+		g.activePiece = testPiece
+		g.emit(Event{Type: Rotate, Turns: turns})
 	}
 }
 
-// TryRotate will check and perform valid rotations
-func (g *Game) tryRotate(clockwise bool) {
-	rotate := func() {
-		if clockwise {
-			g.activePiece.RotateClockwise()
-		} else {
-			g.activePiece.RotateCounterClockwise()
-		}
-	}
+// Move a tetromino to the spawn position with its top block on the spawn
+// row, falling back to the alternate spawn above it if blocked
+func (g *Game) placeAtSpawn(t *Tetromino) {
+	t.move(g.board.spawnX, g.board.spawnY)
+	t.move(t.x, t.y+g.board.spawnY-t.top())
 
-	testRotation := func(t Tetromino) bool {
-		if clockwise {
-			t.RotateClockwise()
-		} else {
-			t.RotateCounterClockwise()
-		}
+	if g.collision(*t) {
+		t.move(g.board.altSpawnX, t.y+g.board.altSpawnY-g.board.spawnY)
+	}
+}
 
-		return !g.collision(t)
+// Swap the active piece with the held piece, or with the next
+// piece if nothing is held yet. Only one hold is allowed per piece
+func (g *Game) tryHold() {
+	if !g.mode.Hold || g.holdUsed {
+		return
 	}
 
-	if testRotation(g.activePiece) {
-		rotate()
+	held := g.activePiece.withSystem(g.rotation)
+	if g.holding {
+		g.activePiece = g.holdPiece
 	} else {
-		testPiece := g.activePiece
-		testPiece.ShiftRight()
-		if testRotation(testPiece) {
-			g.activePiece.ShiftRight()
-			rotate()
-		} else {
-			testPiece = g.activePiece
-			testPiece.ShiftLeft()
-			if testRotation(testPiece) {
-				g.activePiece.ShiftLeft()
-				rotate()
-			}
-		}
+		g.activePiece = g.nextTetromino()
 	}
+	g.holdPiece, g.holding, g.holdUsed = held, true, true
 
+	g.placeAtSpawn(&g.activePiece)
+	g.lockFrames = 0
+	g.gravFrames = 0
+	g.spawnGravity()
 }
 
-var tgmBagHistory []int32
-var tgmFirstPiece bool
-
-// ResetTGMRandomizer seeds generator and resets bag history
-func ResetTGMRandomizer() {
-	rand.Seed(time.Now().UTC().UnixNano())
-	tgmBagHistory = []int32{Z, Z, Z, Z}
-	tgmFirstPiece = true
+// HoldPiece returns the held piece and whether one is held
+func (g Game) HoldPiece() (Tetromino, bool) {
+	return g.holdPiece, g.holding
 }
 
-// NextTGMRandomizer gets the next Tetromino according to tgm randomization rules
-func NextTGMRandomizer() Tetromino {
-	tS := rand.Int31n(tetrominos)
-
-	// The game never deals an S, Z or O as the first piece
-	if tgmFirstPiece {
-		for tS == S || tS == Z || tS == O {
-			tS = rand.Int31n(tetrominos)
-		}
+// TryShift will check and perform valid shift
+func (g *Game) tryShift(right bool) {
+	testPiece := g.activePiece
+	if right {
+		testPiece.ShiftRight()
+	} else {
+		testPiece.ShiftLeft()
 	}
 
-	// Attempt to get a tetronimo not in the bag history
-	for _, t := range tgmBagHistory {
-		if tS == t {
-			tS = rand.Int31n(tetrominos)
+	if !g.collision(testPiece) {
+		if right {
+			g.activePiece.ShiftRight()
+		} else {
+			g.activePiece.ShiftLeft()
 		}
+		g.emit(Event{Type: Shift})
+		g.highGravity()
 	}
-
-	for i := len(tgmBagHistory) - 1; i > 0; i-- {
-		tgmBagHistory[i] = tgmBagHistory[i-1]
-	}
-	tgmBagHistory = append([]int32{tS}, tgmBagHistory[1:4]...)
-	tgmFirstPiece = false
-
-	return generateTetronimo(tS)
 }
 
 var tgmAudio = map[string]string{
@@ -596,6 +870,19 @@ var tgmGrading = map[string]int{
 	"S9": 120000,
 }
 
+// Levels of the gravity table in ascending order
+var tgmGravityLevels = sortedLevels(tgmGravity)
+
+func sortedLevels(table map[int]float64) []int {
+	levels := make([]int, 0, len(table))
+	for level := range table {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	return levels
+}
+
 // GetTGMGravityMap get tgm grav rules
 func GetTGMGravityMap() map[int]float64 {
 	return tgmGravity
@@ -0,0 +1,55 @@
+package tetris
+
+import "time"
+
+// GMGrade is awarded at level 999 to players meeting every GM checkpoint
+const GMGrade = "GM"
+
+// A level the player must reach within a time limit and with a
+// minimum score to stay eligible for the GM grade
+type gmCheckpoint struct {
+	level int
+	time  time.Duration
+	score int
+}
+
+var tgmGMCheckpoints = []gmCheckpoint{
+	{level: 300, time: 4*time.Minute + 15*time.Second, score: 12000},
+	{level: 500, time: 7*time.Minute + 30*time.Second, score: 40000},
+	{level: 999, time: 13*time.Minute + 30*time.Second, score: 126000},
+}
+
+// GMEligible returns true while the player has met every GM checkpoint so far
+func (g Game) GMEligible() bool {
+	return g.gmEligible
+}
+
+// Evaluate the next GM checkpoint against the game clock. A checkpoint
+// fails as soon as its time limit passes without reaching its level, or
+// when it is reached below the score minimum
+func (g *Game) checkGM() {
+	if !g.gmEligible || g.gmCheckpoint >= len(tgmGMCheckpoints) {
+		return
+	}
+
+	cp := tgmGMCheckpoints[g.gmCheckpoint]
+	if g.Time() > cp.time {
+		g.gmEligible = false
+		return
+	}
+
+	if g.level < cp.level {
+		return
+	}
+
+	if g.score < cp.score {
+		g.gmEligible = false
+		return
+	}
+
+	g.gmCheckpoint++
+	if g.gmCheckpoint == len(tgmGMCheckpoints) {
+		g.grade = GMGrade
+		g.emit(Event{Type: GradeUp, Grade: g.grade})
+	}
+}
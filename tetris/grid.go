@@ -1,46 +1,49 @@
 package tetris
 
-import "github.com/veandco/go-sdl2/sdl"
+// Board visibility modes
+const (
+	BoardVisible   = iota
+	BoardInvisible // locked cells are hidden as soon as they lock
+	BoardFading    // locked cells are hidden once they have been locked for fadeFrames
+)
 
 type cell struct {
-	color    sdl.Color
-	rect     sdl.Rect
+	color    Color
 	occupied bool
+	age      int
 }
 
 // Grid - game board
 type Grid struct {
-	cellSize  int32
-	x         int32
-	y         int32
-	spawnX    int32
-	spawnY    int32
-	altSpawnX int32
-	altSpawnY int32
+	spawnX    int
+	spawnY    int
+	altSpawnX int
+	altSpawnY int
 	width     int
 	height    int
 	cells     [][]cell
+
+	visibility int
+	fadeFrames int
 }
 
 // NewGrid creates new tetris grid
-func NewGrid(x, y, cellSize int32, width, height int) Grid {
+func NewGrid(width, height int) Grid {
 	var g Grid
-	g.x, g.y = x, y
 	g.width, g.height = width, height
-	g.cellSize = cellSize
 	g.createGrid()
-	g.spawnX, g.spawnY = g.cells[0][3].rect.X, g.cells[0][3].rect.Y-g.cellSize
-	g.altSpawnX, g.altSpawnY = g.spawnX, g.spawnY-g.cellSize*2
+	// Pieces spawn with their top block on row spawnY
+	g.spawnX, g.spawnY = 3, 0
+	g.altSpawnX, g.altSpawnY = g.spawnX, g.spawnY-2
 	return g
 }
 
 // Create an empty row
-func (g *Grid) createRow(row int) []cell {
+func (g *Grid) createRow() []cell {
 	r := make([]cell, g.width)
 
 	for column := range r {
 		r[column].color = Black
-		r[column].rect = sdl.Rect{X: g.x + int32(column)*g.cellSize, Y: int32(row) * g.cellSize, W: g.cellSize, H: g.cellSize}
 		r[column].occupied = false
 	}
 
@@ -51,17 +54,72 @@ func (g *Grid) createGrid() {
 	g.cells = make([][]cell, g.height)
 
 	for row := range g.cells {
-		g.cells[row] = g.createRow(row)
+		g.cells[row] = g.createRow()
+	}
+}
+
+// Inside returns true if the position lies within the grid
+func (g Grid) Inside(x, y int) bool {
+	return x >= 0 && x < g.width && y >= 0 && y < g.height
+}
+
+// Cell returns the color of the cell at x, y and whether it is occupied
+func (g Grid) Cell(x, y int) (Color, bool) {
+	if !g.Inside(x, y) {
+		return Black, false
+	}
+
+	c := g.cells[y][x]
+	return c.color, c.occupied
+}
+
+// Occupied returns true if the cell at x, y holds a locked block
+func (g Grid) Occupied(x, y int) bool {
+	_, occupied := g.Cell(x, y)
+	return occupied
+}
+
+// fill marks the cell at x, y as occupied with the given color
+func (g *Grid) fill(x, y int, c Color) {
+	if !g.Inside(x, y) {
+		return
+	}
+
+	g.cells[y][x].occupied = true
+	g.cells[y][x].color = c
+	g.cells[y][x].age = 0
+}
+
+// SetVisibility sets how locked cells are shown, fadeFrames is
+// only used by BoardFading. Hidden cells still collide
+func (g *Grid) SetVisibility(visibility, fadeFrames int) {
+	g.visibility, g.fadeFrames = visibility, fadeFrames
+}
+
+// Visible returns true if the cell at x, y holds a locked block
+// which should be drawn
+func (g Grid) Visible(x, y int) bool {
+	if !g.Occupied(x, y) {
+		return false
+	}
+
+	switch g.visibility {
+	case BoardInvisible:
+		return false
+	case BoardFading:
+		return g.cells[y][x].age < g.fadeFrames
 	}
+
+	return true
 }
 
-// Draw draws the grid with its locked pieces
-func (g Grid) Draw(r *sdl.Renderer) {
+// Age the locked cells by a frame
+func (g *Grid) tick() {
 	for _, row := range g.cells {
-		for _, col := range row {
-			//fmt.Printf("%v\n", col.color)
-			r.SetDrawColor(col.color.R, col.color.G, col.color.B, col.color.A)
-			r.FillRect(&col.rect)
+		for col := range row {
+			if row[col].occupied {
+				row[col].age++
+			}
 		}
 	}
 }
@@ -93,26 +151,3 @@ func (g Grid) Width() int {
 func (g Grid) Height() int {
 	return g.height
 }
-
-// PixelWidth retunrs width in pixels
-func (g Grid) PixelWidth() int32 {
-	return int32(g.width) * g.cellSize
-}
-
-// PixelHeight returns width in pixels
-func (g Grid) PixelHeight() int32 {
-	return int32(g.height) * g.cellSize
-}
-
-// CellSize returns cellSize
-func (g Grid) CellSize() int32 {
-	return g.cellSize
-}
-
-func (g Grid) X() int32 {
-	return g.x
-}
-
-func (g Grid) Y() int32 {
-	return g.y
-}
@@ -0,0 +1,87 @@
+package tetris
+
+// Input is a set of commands, one bit per command
+type Input uint32
+
+// Has returns true if command is in the set
+func (in Input) Has(command int32) bool {
+	return in&(1<<uint(command)) != 0
+}
+
+func (in Input) with(command int32) Input {
+	return in | 1<<uint(command)
+}
+
+func (in Input) without(command int32) Input {
+	return in &^ (1 << uint(command))
+}
+
+// InputState is the state of every command during a frame
+type InputState struct {
+	Held     Input // down during the frame
+	Pressed  Input // down during the frame but not the last
+	Released Input // down during the last frame but not this one
+}
+
+// The rotation commands in the order they are tried when several are
+// pressed on the same frame
+var rotationCommands = []int32{RotateClockwise, RotateCounterClockwise, Rotate180}
+
+// Press marks a command as held down until it is released
+func (g *Game) Press(command int32) {
+	g.keys = g.keys.with(command)
+	g.taps = g.taps.with(command)
+}
+
+// Release marks a command as no longer held down
+func (g *Game) Release(command int32) {
+	g.keys = g.keys.without(command)
+}
+
+// Input returns the state of every command during the last processed frame
+func (g Game) Input() InputState {
+	return g.input
+}
+
+// Sample the commands for the frame. A command pressed and released
+// between two frames is still seen as held for one frame
+func (g *Game) readInput() {
+	last := g.input.Held
+	held := g.keys | g.taps
+	g.taps = 0
+
+	g.input = InputState{
+		Held:     held,
+		Pressed:  held &^ last,
+		Released: last &^ held,
+	}
+}
+
+// Commands held during the frame before
+func (s InputState) lastHeld() Input {
+	return s.Held&^s.Pressed | s.Released
+}
+
+// Direction of a held shift, -1 left, 1 right and 0 when neither or
+// both are held
+func shiftDirection(in Input) int {
+	left, right := in.Has(ShiftLeft), in.Has(ShiftRight)
+	if left && !right {
+		return -1
+	} else if right && !left {
+		return 1
+	}
+
+	return 0
+}
+
+// The first rotation command held, 0 if none are
+func (s InputState) heldRotation() int32 {
+	for _, c := range rotationCommands {
+		if s.Held.Has(c) {
+			return c
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,96 @@
+package tetris
+
+// MaxPreviews is the largest number of upcoming pieces a mode may show
+const MaxPreviews int = 6
+
+// Ghost piece policies
+const (
+	GhostNever = iota
+	GhostAlways
+	GhostBeginner // levels 0 to 100 only, as in TGM
+)
+
+// Mode describes the rules a game is played under
+type Mode struct {
+	Name       string
+	Randomizer string
+	Rotation   string
+	Previews   int
+	Hold       bool
+	Ghost      int
+	Visibility int
+	FadeFrames int
+	IRS        bool
+}
+
+// Game modes
+var (
+	TGM1Mode = Mode{
+		Name:       "tgm1",
+		Randomizer: "tgm1",
+		Rotation:   "ars",
+		Previews:   1,
+		Hold:       false,
+		Ghost:      GhostBeginner,
+		IRS:        true,
+	}
+	TGM2Mode = Mode{
+		Name:       "tgm2",
+		Randomizer: "tgm2",
+		Rotation:   "ars",
+		Previews:   1,
+		Hold:       false,
+		Ghost:      GhostBeginner,
+		IRS:        true,
+	}
+	GuidelineMode = Mode{
+		Name:       "guideline",
+		Randomizer: "7bag",
+		Rotation:   "srs",
+		Previews:   5,
+		Hold:       true,
+		Ghost:      GhostAlways,
+		IRS:        false,
+	}
+	InvisibleMode = Mode{
+		Name:       "invisible",
+		Randomizer: "tgm2",
+		Rotation:   "ars",
+		Previews:   1,
+		Hold:       false,
+		Ghost:      GhostNever,
+		Visibility: BoardInvisible,
+		IRS:        true,
+	}
+	FadingMode = Mode{
+		Name:       "fading",
+		Randomizer: "tgm2",
+		Rotation:   "ars",
+		Previews:   1,
+		Hold:       false,
+		Ghost:      GhostNever,
+		Visibility: BoardFading,
+		FadeFrames: 300,
+		IRS:        true,
+	}
+)
+
+// Modes maps mode names to their rules
+var Modes = map[string]Mode{
+	TGM1Mode.Name:      TGM1Mode,
+	TGM2Mode.Name:      TGM2Mode,
+	GuidelineMode.Name: GuidelineMode,
+	InvisibleMode.Name: InvisibleMode,
+	FadingMode.Name:    FadingMode,
+}
+
+// Number of previews clamped between 0 and MaxPreviews
+func (m Mode) previews() int {
+	if m.Previews < 0 {
+		return 0
+	} else if m.Previews > MaxPreviews {
+		return MaxPreviews
+	}
+
+	return m.Previews
+}
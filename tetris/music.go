@@ -0,0 +1,38 @@
+package tetris
+
+// A section of the game played to a music track from its starting level
+type musicSection struct {
+	level int
+	track string
+}
+
+var tgmMusic = []musicSection{
+	{level: 0, track: "easy"},
+	{level: 500, track: "hard"},
+}
+
+// Number of levels before a section change over which the music fades out
+const musicFadeLevels int = 15
+
+// Start the track of the first section
+func (g *Game) startMusic() {
+	g.musicSection, g.musicFading = 0, false
+	g.emit(Event{Type: MusicPlay, Track: tgmMusic[0].track})
+}
+
+// Fade the music out approaching the next section and switch
+// to its track once the level reaches it
+func (g *Game) directMusic() {
+	next := g.musicSection + 1
+	if next >= len(tgmMusic) {
+		return
+	}
+
+	if g.level >= tgmMusic[next].level {
+		g.musicSection, g.musicFading = next, false
+		g.emit(Event{Type: MusicPlay, Track: tgmMusic[next].track})
+	} else if !g.musicFading && g.level >= tgmMusic[next].level-musicFadeLevels {
+		g.musicFading = true
+		g.emit(Event{Type: MusicFadeOut})
+	}
+}
@@ -0,0 +1,73 @@
+package tetris
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Randomizer deals the sequence of tetrominos for a game. Each game
+// owns its own randomizer so that games sharing a seed deal the same
+// sequence of pieces
+type Randomizer interface {
+	Next() Tetromino
+}
+
+// Randomizers maps randomizer names to constructors taking a random source
+var Randomizers = map[string]func(src rand.Source) Randomizer{
+	"tgm1":   func(src rand.Source) Randomizer { return NewHistoryRandomizer(src, TGM1) },
+	"tgm2":   func(src rand.Source) Randomizer { return NewHistoryRandomizer(src, TGM2) },
+	"tgm3":   func(src rand.Source) Randomizer { return NewTGM3Randomizer(src) },
+	"7bag":   func(src rand.Source) Randomizer { return NewBagRandomizer(src, 1) },
+	"14bag":  func(src rand.Source) Randomizer { return NewBagRandomizer(src, 2) },
+	"nes":    func(src rand.Source) Randomizer { return NewNESRandomizer(src) },
+	"random": func(src rand.Source) Randomizer { return NewUniformRandomizer(src) },
+}
+
+// NewRandomizer returns the named randomizer seeded with seed
+func NewRandomizer(name string, seed int64) (Randomizer, error) {
+	create, ok := Randomizers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown randomizer %q", name)
+	}
+
+	return create(rand.NewSource(seed)), nil
+}
+
+// UniformRandomizer deals every piece with equal probability
+type UniformRandomizer struct {
+	rng *rand.Rand
+}
+
+// NewUniformRandomizer returns a pure random randomizer drawing from src
+func NewUniformRandomizer(src rand.Source) *UniformRandomizer {
+	return &UniformRandomizer{rng: rand.New(src)}
+}
+
+// Next gets the next Tetromino
+func (r *UniformRandomizer) Next() Tetromino {
+	return generateTetronimo(r.rng.Int31n(tetrominos))
+}
+
+// NESRandomizer rerolls once if the piece repeats the last one dealt
+type NESRandomizer struct {
+	rng  *rand.Rand
+	last int32
+}
+
+// NewNESRandomizer returns a NES style randomizer drawing from src
+func NewNESRandomizer(src rand.Source) *NESRandomizer {
+	return &NESRandomizer{rng: rand.New(src), last: -1}
+}
+
+// Next gets the next Tetromino according to NES randomization rules
+func (r *NESRandomizer) Next() Tetromino {
+	// Roll one more than the number of pieces, the extra
+	// value forcing a reroll just like a repeated piece
+	tS := r.rng.Int31n(tetrominos + 1)
+	if tS == tetrominos || tS == r.last {
+		tS = r.rng.Int31n(tetrominos)
+	}
+	r.last = tS
+
+	return generateTetronimo(tS)
+}
@@ -0,0 +1,52 @@
+package tetris
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNESRandomizerRepeats(t *testing.T) {
+	draws := 5000000
+	if testing.Short() {
+		draws = 500000
+	}
+
+	r := NewNESRandomizer(rand.NewSource(1))
+	last := r.Next().Shape()
+	repeats := 0
+	for i := 0; i < draws; i++ {
+		s := r.Next().Shape()
+		if s == last {
+			repeats++
+		}
+		last = s
+	}
+
+	// The first roll of 8 repeats or asks for a reroll with chance 2/8,
+	// the reroll of 7 then repeats with chance 1/7
+	want := 2.0 / 8 / 7
+	got := float64(repeats) / float64(draws)
+	if tolerance := 5 * math.Sqrt(want*(1-want)/float64(draws)); math.Abs(got-want) > tolerance {
+		t.Errorf("repeated %.5f of the time, want %.5f", got, want)
+	}
+}
+
+func TestRandomizersSeed(t *testing.T) {
+	for name := range Randomizers {
+		a, _ := NewRandomizer(name, 42)
+		b, _ := NewRandomizer(name, 42)
+
+		for i := 0; i < 1000; i++ {
+			if x, y := a.Next().Shape(), b.Next().Shape(); x != y {
+				t.Fatalf("%s: draw %d dealt %d and %d from the same seed", name, i, x, y)
+			}
+		}
+	}
+}
+
+func TestNewRandomizerUnknown(t *testing.T) {
+	if _, err := NewRandomizer("none", 1); err == nil {
+		t.Error("unknown randomizer created without an error")
+	}
+}
@@ -0,0 +1,50 @@
+package tetris
+
+// Frames the READY and GO messages are shown for before the first piece spawns
+const readyDelay int = 60
+const goDelay int = 60
+
+// Countdown returns the start message shown before the first piece
+// spawns, empty outside of the countdown
+func (g Game) Countdown() string {
+	if g.step != Ready {
+		return ""
+	} else if g.readyFrames < readyDelay {
+		return "READY"
+	}
+
+	return "GO"
+}
+
+// Run a frame of the READY... GO countdown. Holding a shift charges DAS
+// and the last rotation pressed is buffered, both carrying over to the
+// first piece which spawns once the countdown ends. The buffered
+// rotation applies whether or not the mode has IRS
+func (g *Game) processReady() {
+	g.readyFrames++
+
+	g.chargeDAS()
+
+	for _, c := range rotationCommands {
+		if g.input.Pressed.Has(c) {
+			g.readyRotation = c
+		}
+	}
+
+	if g.readyFrames < readyDelay+goDelay {
+		return
+	}
+
+	g.activePiece = g.nextTetromino()
+	g.SpawnTetromino(&g.activePiece)
+	// A rotation pressed before the countdown and still held gets IRS
+	// as on any other spawn
+	if g.readyRotation != 0 {
+		g.spawnRotation(g.readyRotation)
+	} else {
+		g.initialRotation(g.input.heldRotation())
+	}
+	g.spawnGravity()
+
+	g.step = Locking
+}
@@ -0,0 +1,95 @@
+package tetris
+
+import "fmt"
+
+// RotationSystem owns the layout of each piece in each orientation, the
+// orientation pieces spawn in and the kicks tried when a rotation is blocked
+type RotationSystem interface {
+	// Layout returns the width of the piece's bounding box and the box
+	// indices, read left to right and top to bottom, of its four blocks
+	Layout(shape int32, orientation int) (int, [4]int)
+
+	// Orientations returns the number of distinct orientations of a shape
+	Orientations(shape int32) int
+
+	// SpawnOrientation returns the orientation a shape enters the board in
+	SpawnOrientation(shape int32) int
+
+	// Rotate turns t clockwise by quarter turns, negative turning counter
+	// clockwise, trying the system's kicks until one is not blocked. The
+	// rotated piece is returned with false if every test was blocked
+	Rotate(t Tetromino, turns int, blocked func(Point) bool) (Tetromino, bool)
+}
+
+// Rotation systems
+var (
+	ARS RotationSystem = arsSystem{}
+	SRS RotationSystem = srsSystem{}
+
+	// SRSPlus is SRS with kicks for 180 degree rotations
+	SRSPlus RotationSystem = srsSystem{kicks180: true}
+)
+
+// RotationSystems maps rotation system names to their implementations
+var RotationSystems = map[string]RotationSystem{
+	"ars":  ARS,
+	"srs":  SRS,
+	"srs+": SRSPlus,
+}
+
+// NewRotationSystem returns the rotation system registered under name
+func NewRotationSystem(name string) (RotationSystem, error) {
+	rs, ok := RotationSystems[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rotation system %q", name)
+	}
+
+	return rs, nil
+}
+
+// Try the kicks in order, each an offset from the rotated piece, returning
+// the first that is not blocked
+func firstKick(rotated Tetromino, kicks []Point, blocked func(Point) bool) (Tetromino, bool) {
+	for _, k := range kicks {
+		kicked := rotated.moved(k.X, k.Y)
+		if !kicked.collides(blocked) {
+			return kicked, true
+		}
+	}
+
+	return rotated, false
+}
+
+// Quarter turns clockwise made by a rotation command, 0 for commands
+// which do not rotate
+func rotationTurns(command int32) int {
+	switch command {
+	case RotateClockwise:
+		return 1
+	case RotateCounterClockwise:
+		return -1
+	case Rotate180:
+		return 2
+	}
+
+	return 0
+}
+
+// TryRotate will check and perform valid rotations under the game's
+// rotation system
+func (g *Game) tryRotate(turns int) {
+	if !g.activePiece.reorients(turns) {
+		return
+	}
+
+	if rotated, ok := g.rotation.Rotate(g.activePiece, turns, g.blocked); ok {
+		g.rotateTo(rotated, turns)
+	}
+}
+
+// Replace the active piece with its rotation
+func (g *Game) rotateTo(t Tetromino, turns int) {
+	g.activePiece = t
+	g.emit(Event{Type: Rotate, Turns: turns})
+	g.highGravity()
+}
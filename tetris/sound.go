@@ -0,0 +1,88 @@
+package tetris
+
+import "log"
+
+// Milliseconds taken to fade the music out ahead of a section change
+const musicFadeTime int = 4000
+
+var tgmEffects = map[string]string{
+	"shift":   "assets/sfx/shift.wav",
+	"rotate":  "assets/sfx/rotate.wav",
+	"lock":    "assets/sfx/lock.wav",
+	"clear":   "assets/sfx/clear.wav",
+	"section": "assets/sfx/section.wav",
+	"grade":   "assets/sfx/grade.wav",
+	"next_i":  "assets/sfx/next_i.wav",
+	"next_j":  "assets/sfx/next_j.wav",
+	"next_l":  "assets/sfx/next_l.wav",
+	"next_o":  "assets/sfx/next_o.wav",
+	"next_s":  "assets/sfx/next_s.wav",
+	"next_t":  "assets/sfx/next_t.wav",
+	"next_z":  "assets/sfx/next_z.wav",
+}
+
+// The next piece cue played on spawn by upcoming tetromino type
+var nextEffects = map[int32]string{
+	I: "next_i",
+	J: "next_j",
+	L: "next_l",
+	O: "next_o",
+	S: "next_s",
+	T: "next_t",
+	Z: "next_z",
+}
+
+// LoadAudio loads the music and sound effects, an asset which fails to
+// load is logged and skipped so the game plays on without it
+func (g *Game) loadAudio() {
+	for k, v := range tgmAudio {
+		if err := g.audio.LoadMusic(k, v); err != nil {
+			log.Printf("music %q: %v\n", k, err)
+		}
+	}
+
+	for k, v := range tgmEffects {
+		if err := g.audio.LoadEffect(k, v); err != nil {
+			log.Printf("effect %q: %v\n", k, err)
+		}
+	}
+}
+
+// Play the music and sound effects for the events of the frame
+func (g *Game) playAudio() {
+	for _, e := range g.events {
+		switch e.Type {
+		case MusicPlay:
+			g.playMusic(e.Track)
+		case MusicFadeOut:
+			g.audio.FadeOutMusic(musicFadeTime)
+		case Shift:
+			g.playEffect("shift")
+		case Rotate:
+			g.playEffect("rotate")
+		case Lock:
+			g.playEffect("lock")
+		case LineClear:
+			g.playEffect("clear")
+		case SectionUp:
+			g.playEffect("section")
+		case GradeUp:
+			g.playEffect("grade")
+		case NextPiece:
+			g.playEffect(nextEffects[e.Piece])
+		}
+	}
+}
+
+// Loop a track, logging it if it fails to play
+func (g *Game) playMusic(track string) {
+	if err := g.audio.PlayMusic(track, -1); err != nil {
+		log.Printf("music %q: %v\n", track, err)
+	}
+}
+
+func (g *Game) playEffect(name string) {
+	if err := g.audio.PlayEffect(name); err != nil {
+		log.Printf("effect %q: %v\n", name, err)
+	}
+}
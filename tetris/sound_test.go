@@ -0,0 +1,65 @@
+package tetris
+
+import (
+	"strings"
+	"testing"
+)
+
+// Returns the index of the first effect at or after from with the prefix, -1 if none
+func findEffect(effects []string, from int, prefix string) int {
+	for i := from; i < len(effects); i++ {
+		if strings.HasPrefix(effects[i], prefix) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestStartPlaysTransitionTrack(t *testing.T) {
+	rec := &RecordingAudio{}
+	g := NewGame(TGM1Mode, 1, rec)
+	g.Init()
+
+	g.Press(Start)
+	g.ProcessFrame()
+
+	if len(rec.Tracks) != 2 || rec.Tracks[0] != "menu" || rec.Tracks[1] != "start" {
+		t.Fatalf("tracks %v, want [menu start]", rec.Tracks)
+	}
+}
+
+func TestLockAndSpawnPlayEffects(t *testing.T) {
+	rec := &RecordingAudio{}
+	g := NewGame(TGM1Mode, 1, rec)
+	g.Init()
+
+	g.Press(Start)
+	g.ProcessFrame()
+	g.Release(Start)
+
+	for i := 0; g.Step() != Locking; i++ {
+		if i > 10*FPS {
+			t.Fatal("first piece never spawned")
+		}
+		g.ProcessFrame()
+	}
+
+	if findEffect(rec.Effects, 0, "next_") < 0 {
+		t.Fatalf("effects %v, want a next piece cue on the first spawn", rec.Effects)
+	}
+
+	// Drop the piece until it locks and the next one spawns
+	from := len(rec.Effects)
+	g.Press(ManualDrop)
+	for i := 0; ; i++ {
+		if i > 10*FPS {
+			t.Fatalf("effects %v, want a lock followed by a next piece cue", rec.Effects[from:])
+		}
+		g.ProcessFrame()
+
+		if lock := findEffect(rec.Effects, from, "lock"); lock >= 0 && findEffect(rec.Effects, lock, "next_") >= 0 {
+			break
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package tetris
+
+// SRS orientations, named as in the guideline
+const (
+	srsSpawn = iota // 0
+	srsRight        // R
+	srsFlip         // 2
+	srsLeft         // L
+)
+
+// Block layouts in each orientation, clockwise from spawn
+var srsLayouts = map[int32][4][4]int{
+	I: {{4, 5, 6, 7}, {2, 6, 10, 14}, {8, 9, 10, 11}, {1, 5, 9, 13}},
+	J: {{0, 3, 4, 5}, {1, 2, 4, 7}, {3, 4, 5, 8}, {1, 4, 6, 7}},
+	L: {{2, 3, 4, 5}, {1, 4, 7, 8}, {3, 4, 5, 6}, {0, 1, 4, 7}},
+	O: {{1, 2, 4, 5}, {1, 2, 4, 5}, {1, 2, 4, 5}, {1, 2, 4, 5}},
+	S: {{1, 2, 3, 4}, {1, 4, 5, 8}, {4, 5, 6, 7}, {0, 3, 4, 7}},
+	T: {{1, 3, 4, 5}, {1, 4, 5, 7}, {3, 4, 5, 7}, {1, 3, 4, 7}},
+	Z: {{0, 1, 4, 5}, {2, 4, 5, 7}, {3, 4, 7, 8}, {1, 3, 4, 6}},
+}
+
+// Kick tests by starting and ending orientation. The offsets are written
+// as published, with y pointing up, and flipped onto the board's rows
+// when tried
+var srsKicks = map[[2]int][]Point{
+	{srsSpawn, srsRight}: {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{srsRight, srsSpawn}: {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+	{srsRight, srsFlip}:  {{0, 0}, {1, 0}, {1, -1}, {0, 2}, {1, 2}},
+	{srsFlip, srsRight}:  {{0, 0}, {-1, 0}, {-1, 1}, {0, -2}, {-1, -2}},
+	{srsFlip, srsLeft}:   {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+	{srsLeft, srsFlip}:   {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{srsLeft, srsSpawn}:  {{0, 0}, {-1, 0}, {-1, -1}, {0, 2}, {-1, 2}},
+	{srsSpawn, srsLeft}:  {{0, 0}, {1, 0}, {1, 1}, {0, -2}, {1, -2}},
+}
+
+// The I piece has its own kick tests
+var srsIKicks = map[[2]int][]Point{
+	{srsSpawn, srsRight}: {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+	{srsRight, srsSpawn}: {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+	{srsRight, srsFlip}:  {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+	{srsFlip, srsRight}:  {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+	{srsFlip, srsLeft}:   {{0, 0}, {2, 0}, {-1, 0}, {2, 1}, {-1, -2}},
+	{srsLeft, srsFlip}:   {{0, 0}, {-2, 0}, {1, 0}, {-2, -1}, {1, 2}},
+	{srsLeft, srsSpawn}:  {{0, 0}, {1, 0}, {-2, 0}, {1, -2}, {-2, 1}},
+	{srsSpawn, srsLeft}:  {{0, 0}, {-1, 0}, {2, 0}, {-1, 2}, {2, -1}},
+}
+
+// Kick tests for 180 degree rotations, as popularised by TETR.IO
+var srs180Kicks = map[[2]int][]Point{
+	{srsSpawn, srsFlip}: {{0, 0}, {0, 1}, {1, 1}, {-1, 1}, {1, 0}, {-1, 0}},
+	{srsRight, srsLeft}: {{0, 0}, {1, 0}, {1, 2}, {1, 1}, {0, 2}, {0, 1}},
+	{srsFlip, srsSpawn}: {{0, 0}, {0, -1}, {-1, -1}, {1, -1}, {-1, 0}, {1, 0}},
+	{srsLeft, srsRight}: {{0, 0}, {-1, 0}, {-1, 2}, {-1, 1}, {0, 2}, {0, 1}},
+}
+
+// The Super Rotation System used by guideline games. Without kicks180 a
+// 180 degree rotation is only tried in place
+type srsSystem struct {
+	kicks180 bool
+}
+
+// Layout returns the SRS box width and block indices
+func (srsSystem) Layout(shape int32, orientation int) (int, [4]int) {
+	box := 3
+	if shape == I {
+		box = 4
+	}
+
+	return box, srsLayouts[shape][orientation]
+}
+
+// Orientations is four for every shape
+func (srsSystem) Orientations(shape int32) int {
+	return 4
+}
+
+// SpawnOrientation is always the spawn state
+func (srsSystem) SpawnOrientation(shape int32) int {
+	return srsSpawn
+}
+
+// Rotate tries each of the kick tests for the turn in order, the first
+// being the basic rotation
+func (s srsSystem) Rotate(t Tetromino, turns int, blocked func(Point) bool) (Tetromino, bool) {
+	rotated := t.turned(turns)
+	kicks := s.kicks(t.shape, t.orientation, rotated.orientation)
+
+	flipped := make([]Point, len(kicks))
+	for i, k := range kicks {
+		flipped[i] = Point{X: k.X, Y: -k.Y}
+	}
+
+	return firstKick(rotated, flipped, blocked)
+}
+
+// Kick tests for a shape turning between two orientations. The O piece
+// and 180 degree turns without kicks180 only try the basic rotation
+func (s srsSystem) kicks(shape int32, from, to int) []Point {
+	key := [2]int{from, to}
+	basic := []Point{{X: 0, Y: 0}}
+
+	if shape == O {
+		return basic
+	}
+
+	if to == (from+2)%4 {
+		if s.kicks180 {
+			return srs180Kicks[key]
+		}
+		return basic
+	}
+
+	if shape == I {
+		return srsIKicks[key]
+	}
+
+	return srsKicks[key]
+}
@@ -1,22 +1,25 @@
 package tetris
 
-import (
-	"fmt"
-	"math"
+// Color is an RGBA colour used to tint tetrominos and locked cells
+type Color struct {
+	R, G, B, A uint8
+}
 
-	"github.com/veandco/go-sdl2/sdl"
-)
+// Point is a cell position on the grid, X is the column and Y the row
+type Point struct {
+	X, Y int
+}
 
 // Tetromino color scheme
 var (
-	Red    = sdl.Color{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}
-	Blue   = sdl.Color{R: 0x00, G: 0x00, B: 0xFF, A: 0xFF}
-	Orange = sdl.Color{R: 0xEF, G: 0x79, B: 0x21, A: 0xFF}
-	Yellow = sdl.Color{R: 0xF7, G: 0xD3, B: 0x08, A: 0xFF}
-	Aqua   = sdl.Color{R: 0x31, G: 0xC7, B: 0xEF, A: 0xFF}
-	Purple = sdl.Color{R: 0xAD, G: 0x4D, B: 0x9C, A: 0xFF}
-	Green  = sdl.Color{R: 0x00, G: 0xFF, B: 0x00, A: 0xFF}
-	Black  = sdl.Color{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}
+	Red    = Color{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}
+	Blue   = Color{R: 0x00, G: 0x00, B: 0xFF, A: 0xFF}
+	Orange = Color{R: 0xEF, G: 0x79, B: 0x21, A: 0xFF}
+	Yellow = Color{R: 0xF7, G: 0xD3, B: 0x08, A: 0xFF}
+	Aqua   = Color{R: 0x31, G: 0xC7, B: 0xEF, A: 0xFF}
+	Purple = Color{R: 0xAD, G: 0x4D, B: 0x9C, A: 0xFF}
+	Green  = Color{R: 0x00, G: 0xFF, B: 0x00, A: 0xFF}
+	Black  = Color{R: 0x00, G: 0x00, B: 0x00, A: 0xFF}
 )
 
 const tetrominos int32 = 7
@@ -32,30 +35,28 @@ const (
 	Z
 )
 
-func (t *Tetromino) setBounds(sX int32, sY int32) {
-	t.bounds = make([]sdl.Rect, t.boundaryArea*t.boundaryArea)
-	var x, y int32 = sX, sY
-	for i := 1; i <= t.boundaryArea*t.boundaryArea; i++ {
-		t.bounds[i-1] = sdl.Rect{X: x, Y: y, W: t.size, H: t.size}
-		x += t.size
-
-		if i%t.boundaryArea == 0 {
-			y += t.size
-			x = sX
-		}
-	}
-}
-
 // Tetromino - tetris block
 type Tetromino struct {
 	shape        int32
-	size         int32
-	color        sdl.Color
+	color        Color
+	system       RotationSystem
 	orientation  int
-	orientations int
 	boundaryArea int
-	bounds       []sdl.Rect
-	blocks       [4]sdl.Rect
+	x, y         int
+	blocks       [4]Point
+}
+
+// Create a tetromino of shape in the spawn orientation of a rotation system
+func newTetromino(shape int32, color Color, rs RotationSystem) Tetromino {
+	t := Tetromino{shape: shape, color: color, system: rs}
+	t.orientation = rs.SpawnOrientation(shape)
+	t.setOrientation(t.orientation)
+	return t
+}
+
+// Returns the same piece recreated for another rotation system
+func (t Tetromino) withSystem(rs RotationSystem) Tetromino {
+	return newTetromino(t.shape, t.color, rs)
 }
 
 // Type e.g. I, J, L
@@ -68,115 +69,58 @@ func (t Tetromino) Shape() int32 {
 	return t.shape
 }
 
+// Orientation returns the number of clockwise quarter turns from the
+// rotation system's first orientation
+func (t Tetromino) Orientation() int {
+	return t.orientation
+}
+
 // ITetromino
 // [][][][]
 func ITetromino() Tetromino {
-	var t Tetromino
-	t.shape = I
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 2
-	t.color = Red
-	t.boundaryArea = 4
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(I, Red, ARS)
 }
 
 // JTetromino
 // [][][]
 //     []
 func JTetromino() Tetromino {
-	var t Tetromino
-	t.shape = J
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 4
-	t.color = Blue
-	t.boundaryArea = 3
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(J, Blue, ARS)
 }
 
 // LTetromino
 // [][][]
 // []
 func LTetromino() Tetromino {
-	var t Tetromino
-	t.shape = L
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 4
-	t.color = Orange
-	t.boundaryArea = 3
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(L, Orange, ARS)
 }
 
 // OTetromino
 // [][]
 // [][]
 func OTetromino() Tetromino {
-	var t Tetromino
-	t.shape = O
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 1
-	t.color = Yellow
-	t.boundaryArea = 4
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(O, Yellow, ARS)
 }
 
 // TTetromino
 // [][][]
 //   []
 func TTetromino() Tetromino {
-	var t Tetromino
-	t.shape = T
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 4
-	t.color = Aqua
-	t.boundaryArea = 3
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(T, Aqua, ARS)
 }
 
 // STetromino
 //   [][]
 // [][]
 func STetromino() Tetromino {
-	var t Tetromino
-	t.shape = S
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 2
-	t.color = Purple
-	t.boundaryArea = 3
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(S, Purple, ARS)
 }
 
 // 	ZTetromino
 //  [][]
 // 	  [][]
 func ZTetromino() Tetromino {
-	var t Tetromino
-	t.shape = Z
-	t.size = 10
-	t.orientation = 1
-	t.orientations = 2
-	t.color = Green
-	t.boundaryArea = 3
-	t.setBounds(0, 0)
-	t.setOrientation(t.orientation)
-	return t
+	return newTetromino(Z, Green, ARS)
 }
 
 // GenerateTetronimo creates a tetronimo of the given type
@@ -201,106 +145,58 @@ func generateTetronimo(s int32) Tetromino {
 	}
 }
 
-// [0][1][2]	[0 ][1 ][2 ][3 ]
-// [3][4][5]	[4 ][5 ][6 ][7 ]
-// [6][7][8]	[8 ][9 ][10][11]
-//				[12][13][14][15]
-// Apply rotations according to TGM rotation rules
-func (t *Tetromino) setOrientation(o int) {
-	switch t.shape {
-	case I:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[4], t.bounds[5], t.bounds[6], t.bounds[7]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[2], t.bounds[6], t.bounds[10], t.bounds[14]}
-		}
-	case J:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[3], t.bounds[4], t.bounds[5], t.bounds[8]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[6]}
-		case 3:
-			t.blocks = [4]sdl.Rect{t.bounds[3], t.bounds[6], t.bounds[7], t.bounds[8]}
-		case 4:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[2]}
-		}
-	case L:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[3], t.bounds[4], t.bounds[5], t.bounds[6]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[0]}
-		case 3:
-			t.blocks = [4]sdl.Rect{t.bounds[5], t.bounds[6], t.bounds[7], t.bounds[8]}
-		case 4:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[8]}
-		}
-	case O:
-		t.blocks = [4]sdl.Rect{t.bounds[5], t.bounds[6], t.bounds[9], t.bounds[10]}
-	case T:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[3], t.bounds[4], t.bounds[5], t.bounds[7]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[3]}
-		case 3:
-			t.blocks = [4]sdl.Rect{t.bounds[4], t.bounds[6], t.bounds[7], t.bounds[8]}
-		case 4:
-			t.blocks = [4]sdl.Rect{t.bounds[1], t.bounds[4], t.bounds[7], t.bounds[5]}
-		}
-	case S:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[6], t.bounds[7], t.bounds[4], t.bounds[5]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[0], t.bounds[3], t.bounds[4], t.bounds[7]}
-		}
+// Returns the grid position of index i in the bounding box
+func (t Tetromino) bound(i int) Point {
+	return Point{X: t.x + i%t.boundaryArea, Y: t.y + i/t.boundaryArea}
+}
 
-	case Z:
-		switch o {
-		case 1:
-			t.blocks = [4]sdl.Rect{t.bounds[3], t.bounds[4], t.bounds[7], t.bounds[8]}
-		case 2:
-			t.blocks = [4]sdl.Rect{t.bounds[4], t.bounds[7], t.bounds[5], t.bounds[2]}
-		}
+// Place the blocks as laid out by the rotation system for orientation o
+func (t *Tetromino) setOrientation(o int) {
+	var idx [4]int
+	t.boundaryArea, idx = t.system.Layout(t.shape, o)
 
+	for i, v := range idx {
+		t.blocks[i] = t.bound(v)
 	}
 }
 
-// Blocks returns blocks that make up actual Tetromino
-func (t Tetromino) Blocks() []sdl.Rect {
+// Blocks returns the grid cells that make up actual Tetromino
+func (t Tetromino) Blocks() []Point {
 	return t.blocks[:]
 }
 
 // Color returns tetromino color
-func (t Tetromino) Color() sdl.Color {
+func (t Tetromino) Color() Color {
 	return t.color
 }
 
-// Resize scales the tetromino and its bounding box
-func (t *Tetromino) Resize(d int32) {
-	for i := range t.bounds {
-		if t.bounds[i].X != 0 {
-			t.bounds[i].X = (d / t.bounds[i].W) * t.bounds[i].X
+// Above returns true if any block of the tetromino is above some row
+func (t Tetromino) Above(y int) bool {
+	for _, v := range t.blocks {
+		if v.Y < y {
+			return true
 		}
+	}
 
-		if t.bounds[i].Y != 0 {
-			t.bounds[i].Y = (d / t.bounds[i].W) * t.bounds[i].Y
-		}
+	return false
+}
 
-		t.bounds[i].W = d
-		t.bounds[i].H = d
+// Returns the row of the tetromino's highest block
+func (t Tetromino) top() int {
+	top := t.blocks[0].Y
+	for _, v := range t.blocks {
+		if v.Y < top {
+			top = v.Y
+		}
 	}
 
-	t.size = d
+	return top
 }
 
-// Above returns true if tetromino is above some y value
-func (t Tetromino) Above(y int32) bool {
+// Returns true if any block lands on a cell reported as blocked
+func (t Tetromino) collides(blocked func(Point) bool) bool {
 	for _, v := range t.blocks {
-		if v.Y < y {
+		if blocked(v) {
 			return true
 		}
 	}
@@ -308,71 +204,53 @@ func (t Tetromino) Above(y int32) bool {
 	return false
 }
 
-func (t *Tetromino) move(x int32, y int32) {
-	t.setBounds(x, y)
+func (t *Tetromino) move(x int, y int) {
+	t.x, t.y = x, y
 	t.setOrientation(t.orientation)
 }
 
+// Returns a copy of the tetromino moved by dx columns and dy rows
+func (t Tetromino) moved(dx, dy int) Tetromino {
+	t.move(t.x+dx, t.y+dy)
+	return t
+}
+
+// Returns a copy of the tetromino turned in place by quarter turns,
+// clockwise when positive, without checking for collisions
+func (t Tetromino) turned(turns int) Tetromino {
+	n := t.system.Orientations(t.shape)
+	t.orientation = ((t.orientation+turns)%n + n) % n
+	t.setOrientation(t.orientation)
+	return t
+}
+
+// Returns true if turning by quarter turns changes the orientation, a
+// 180 of a piece with two orientations leaves it as it was
+func (t Tetromino) reorients(turns int) bool {
+	return t.turned(turns).orientation != t.orientation
+}
+
 // ShiftRight shifts tetromino to the right 1 grid space
 func (t *Tetromino) ShiftRight() {
-	t.move(t.bounds[0].X+20, t.bounds[0].Y)
+	t.move(t.x+1, t.y)
 }
 
 // ShiftLeft shifts tetromino to the left 1 grid space
 func (t *Tetromino) ShiftLeft() {
-	t.move(t.bounds[0].X-20, t.bounds[0].Y)
+	t.move(t.x-1, t.y)
 }
 
 // Drop drops Tetromino 1 grid space
 func (t *Tetromino) Drop() {
-	t.move(t.bounds[0].X, t.bounds[0].Y+20)
-}
-
-// Draw uses passed in renderer to draw tetromino
-func (t Tetromino) Draw(r *sdl.Renderer) {
-	// r.SetDrawColor(0x0, 0x0, 0x0, t.color.A)
-	// r.FillRects(t.bounds)
-
-	r.SetDrawColor(t.color.R, t.color.G, t.color.B, t.color.A)
-	r.FillRects(t.Blocks())
+	t.move(t.x, t.y+1)
 }
 
 // RotateClockwise rotates tetromino clockwise
 func (t *Tetromino) RotateClockwise() {
-	t.orientation++
-	if t.orientation > t.orientations {
-		t.orientation = 1
-	}
-
-	t.setOrientation(t.orientation)
+	*t = t.turned(1)
 }
 
 // RotateCounterClockwise rotates tetromino counter-clockwise
 func (t *Tetromino) RotateCounterClockwise() {
-	t.orientation--
-	if t.orientation < 1 {
-		t.orientation = t.orientations
-	}
-
-	t.setOrientation(t.orientation)
-}
-
-// 2D matrix rotations
-func (t *Tetromino) rotate(d float64) {
-	for i, pos := range t.blocks {
-		var oX, oY int32 = (pos.W * 3) / 2, (pos.W * 3) / 2
-		fmt.Printf("Origin X&Y:\t%v\n", oX)
-		dX, dY := oX-pos.X, oY-pos.Y
-
-		t.blocks[i].X = int32(cosDegrees(d)*float64(dX)) + int32(-sinDegrees(d)*float64(dY)) + oX
-		t.blocks[i].Y = int32(sinDegrees(d)*float64(dX)) + int32(cosDegrees(d)*float64(dY)) + oY
-	}
-}
-
-func cosDegrees(d float64) float64 {
-	return math.Cos(d * math.Pi / 180.0)
-}
-
-func sinDegrees(d float64) float64 {
-	return math.Sin(d * math.Pi / 180.0)
+	*t = t.turned(-1)
 }
@@ -0,0 +1,150 @@
+package tetris
+
+import "math/rand"
+
+// History randomizer variants
+const (
+	TGM1 = iota
+	TGM2
+)
+
+// HistoryRandomizer deals tetrominos according to tgm randomization rules,
+// rerolling pieces found in the history of the last 4 pieces dealt
+type HistoryRandomizer struct {
+	rng        *rand.Rand
+	history    [4]int32
+	rolls      int
+	firstPiece bool
+}
+
+// NewHistoryRandomizer returns a randomizer of the given variant
+// drawing from src
+func NewHistoryRandomizer(src rand.Source, variant int) *HistoryRandomizer {
+	r := &HistoryRandomizer{rng: rand.New(src), firstPiece: true}
+
+	switch variant {
+	case TGM2:
+		// The Absolute Plus: history S, Z, S, Z with 6 rolls
+		r.history = [4]int32{S, Z, S, Z}
+		r.rolls = 6
+	default:
+		// Tetris The Grand Master: history Z, Z, Z, Z with 4 rolls
+		r.history = [4]int32{Z, Z, Z, Z}
+		r.rolls = 4
+	}
+
+	return r
+}
+
+// Next gets the next Tetromino according to tgm randomization rules
+func (r *HistoryRandomizer) Next() Tetromino {
+	var tS int32
+
+	if r.firstPiece {
+		tS = firstTGMPiece(r.rng)
+		r.firstPiece = false
+	} else {
+		// Reroll pieces found in the history, keeping the
+		// last roll if every attempt is in the history
+		for i := 0; i < r.rolls; i++ {
+			tS = r.rng.Int31n(tetrominos)
+			if !inHistory(r.history[:], tS) {
+				break
+			}
+		}
+	}
+
+	pushHistory(r.history[:], tS)
+
+	return generateTetronimo(tS)
+}
+
+// TGM3Randomizer deals from a pool of 35 pieces which is refilled
+// with the piece that has gone the longest without being dealt. It
+// follows colour_thief's reverse engineered TGM3 randomizer as published
+// on TetrisConcept, where the drought order starts empty and leaves out
+// the first piece, so early refills only use pieces already dealt
+type TGM3Randomizer struct {
+	rng        *rand.Rand
+	pool       [35]int32
+	order      []int32
+	history    [4]int32
+	firstPiece bool
+}
+
+// NewTGM3Randomizer returns a Ti style randomizer drawing from src
+func NewTGM3Randomizer(src rand.Source) *TGM3Randomizer {
+	r := &TGM3Randomizer{rng: rand.New(src), firstPiece: true}
+	r.history = [4]int32{S, Z, S, Z}
+
+	// 5 of each piece
+	for i := range r.pool {
+		r.pool[i] = int32(i) % tetrominos
+	}
+
+	return r
+}
+
+// Next gets the next Tetromino according to tgm3 randomization rules
+func (r *TGM3Randomizer) Next() Tetromino {
+	if r.firstPiece {
+		tS := firstTGMPiece(r.rng)
+		pushHistory(r.history[:], tS)
+		r.firstPiece = false
+		return generateTetronimo(tS)
+	}
+
+	var tS int32
+	var i int32
+
+	// Roll up to 6 times, every rerolled slot in the pool
+	// is replaced with the most droughted piece
+	for roll := 0; roll < 6; roll++ {
+		i = r.rng.Int31n(int32(len(r.pool)))
+		tS = r.pool[i]
+		if !inHistory(r.history[:], tS) {
+			break
+		}
+
+		if len(r.order) > 0 {
+			r.pool[i] = r.order[0]
+		}
+	}
+
+	// Move the dealt piece to the back of the drought order
+	for j, v := range r.order {
+		if v == tS {
+			r.order = append(r.order[:j], r.order[j+1:]...)
+			break
+		}
+	}
+	r.order = append(r.order, tS)
+	r.pool[i] = r.order[0]
+
+	pushHistory(r.history[:], tS)
+
+	return generateTetronimo(tS)
+}
+
+// The game never deals an S, Z or O as the first piece
+func firstTGMPiece(rng *rand.Rand) int32 {
+	first := [4]int32{I, J, L, T}
+	return first[rng.Intn(len(first))]
+}
+
+// Returns true if the shape is one of the last pieces dealt
+func inHistory(history []int32, s int32) bool {
+	for _, t := range history {
+		if s == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Add a shape to the front of the history, dropping the oldest
+func pushHistory(history []int32, s int32) {
+	copy(history[1:], history[:len(history)-1])
+	history[0] = s
+}
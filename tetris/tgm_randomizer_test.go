@@ -0,0 +1,152 @@
+package tetris
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHistoryRandomizerFirstPiece(t *testing.T) {
+	for _, variant := range []int{TGM1, TGM2} {
+		for seed := int64(0); seed < 1000; seed++ {
+			r := NewHistoryRandomizer(rand.NewSource(seed), variant)
+			switch s := r.Next().Shape(); s {
+			case S, Z, O:
+				t.Fatalf("variant %d seed %d: first piece %d", variant, seed, s)
+			}
+		}
+	}
+}
+
+func TestHistoryRandomizerDistribution(t *testing.T) {
+	draws := 10000000
+	if testing.Short() {
+		draws = 1000000
+	}
+
+	// Every piece is dealt with equal frequency in the long run,
+	// the history only changes the order they come in
+	const tolerance = 0.002
+	want := 1 / float64(tetrominos)
+
+	for _, variant := range []int{TGM1, TGM2} {
+		r := NewHistoryRandomizer(rand.NewSource(1), variant)
+		var counts [tetrominos]int
+		for i := 0; i < draws; i++ {
+			counts[r.Next().Shape()]++
+		}
+
+		for s, n := range counts {
+			got := float64(n) / float64(draws)
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("variant %d: shape %d dealt %.4f of the time, want %.4f", variant, s, got, want)
+			}
+		}
+	}
+}
+
+func TestHistoryRandomizerRerolls(t *testing.T) {
+	draws := 5000000
+	if testing.Short() {
+		draws = 500000
+	}
+
+	tests := []struct {
+		variant int
+		rolls   int
+	}{
+		{TGM1, 4},
+		{TGM2, 6},
+	}
+
+	for _, test := range tests {
+		r := NewHistoryRandomizer(rand.NewSource(1), test.variant)
+		r.Next()
+
+		// Draws and repeats of a piece in the history, by the number of
+		// different pieces the history held at the time
+		var seen, repeats [5]int
+		for i := 0; i < draws; i++ {
+			history := r.history
+			k := distinct(history[:])
+			if inHistory(history[:], r.Next().Shape()) {
+				repeats[k]++
+			}
+			seen[k]++
+		}
+
+		// Every roll lands in a history of k pieces with chance k/7, a
+		// repeat is dealt only when all of them do
+		for k := 1; k <= 4; k++ {
+			if seen[k] < 10000 {
+				continue
+			}
+
+			want := math.Pow(float64(k)/float64(tetrominos), float64(test.rolls))
+			got := float64(repeats[k]) / float64(seen[k])
+			tolerance := 5 * math.Sqrt(want*(1-want)/float64(seen[k]))
+			if math.Abs(got-want) > tolerance {
+				t.Errorf("variant %d: history of %d pieces repeated %.5f of the time, want %.5f",
+					test.variant, k, got, want)
+			}
+		}
+	}
+}
+
+// Number of different pieces in a history
+func distinct(history []int32) int {
+	var found [tetrominos]bool
+	n := 0
+	for _, s := range history {
+		if !found[s] {
+			found[s] = true
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestHistoryRandomizerSeed(t *testing.T) {
+	for _, variant := range []int{TGM1, TGM2} {
+		a := NewHistoryRandomizer(rand.NewSource(42), variant)
+		b := NewHistoryRandomizer(rand.NewSource(42), variant)
+
+		for i := 0; i < 10000; i++ {
+			if x, y := a.Next().Shape(), b.Next().Shape(); x != y {
+				t.Fatalf("variant %d: draw %d dealt %d and %d from the same seed", variant, i, x, y)
+			}
+		}
+	}
+}
+
+func TestTGM3Randomizer(t *testing.T) {
+	r := NewTGM3Randomizer(rand.NewSource(1))
+
+	switch s := r.Next().Shape(); s {
+	case S, Z, O:
+		t.Fatalf("first piece %d", s)
+	}
+
+	// The drought order only holds pieces dealt after the first
+	if len(r.order) != 0 {
+		t.Fatalf("drought order %v after the first piece, want empty", r.order)
+	}
+
+	const draws = 1000000
+	var counts [tetrominos]int
+	for i := 0; i < draws; i++ {
+		counts[r.Next().Shape()]++
+
+		if len(r.order) > int(tetrominos) {
+			t.Fatalf("drought order %v holds a piece twice", r.order)
+		}
+	}
+
+	want := 1 / float64(tetrominos)
+	for s, n := range counts {
+		if got := float64(n) / draws; math.Abs(got-want) > 0.003 {
+			t.Errorf("shape %d dealt %.4f of the time, want %.4f", s, got, want)
+		}
+	}
+}